@@ -7,6 +7,9 @@ import "math/big"
 // qK64 is a pre-calculated quantity equal to k mod R where k=(r(r^−1 mod n)−1)/n.
 const qK64 uint64 = 0x89f3fffcfffcfffd
 
+// rK64 is the quantity equivalent to qK64 for the scalar field, -r^-1 mod 2^64.
+const rK64 uint64 = 0xfffffffeffffffff
+
 var (
 	// q is a prime number that specifies the number of elements of the finite field.
 	q, _ = bigFromBase10("4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559787")
@@ -18,12 +21,53 @@ var (
 	// calculate their inverse.
 	qMinusTwo = &fq{0xB9FEFFFFFFFFAAA9, 0x1EABFFFEB153FFFF, 0x6730D2A0F6B0F624, 0x64774B84F38512BF, 0x4B1BA7B6434BACD7, 0x1A0111EA397FE69A}
 
+	// qMinusThreeOverFour is the value by which to exponentiate q-order field
+	// elements to calculate the candidate square root of a ratio.
+	qMinusThreeOverFour = []uint64{0xee7fbfffffffeaaa, 0x7aaffffac54ffff, 0xd9cc34a83dac3d89, 0xd91dd2e13ce144af, 0x92c6e9ed90d2eb35, 0x680447a8e5ff9a6}
+
+	// qMinusOneOverTwo is the value by which to exponentiate q-order field
+	// elements to calculate their Legendre symbol.
+	qMinusOneOverTwo = []uint64{0xdcff7fffffffd555, 0xf55ffff58a9ffff, 0xb39869507b587b12, 0xb23ba5c279c2895f, 0x258dd3db21a5d66b, 0xd0088f51cbff34d}
+
+	// qSqrMinusNineOverSixteen is the value by which to exponentiate q²-order
+	// field elements to calculate the candidate square root of a ratio.
+	qSqrMinusNineOverSixteen = []uint64{0xb26aa00001c718e3, 0xd7ced6b1d76382ea, 0x3162c338362113cf, 0x966bf91ed3e71b74, 0xb292e85a87091a04, 0x11d68619c86185c7, 0xef53149330978ef0, 0x50a62cfd16ddca6, 0x466e59e49349e8bd, 0x9e2dc90e50e7046b, 0x74bd278eaa22f25e, 0x2a437a4b8c35fc}
+
 	// r2Q is the value by which to multiply q-order field elements to map them to the Montgomery domain.
 	qR2 = &fq{0xf4df1f341c341746, 0x0a76e6a609d104f1, 0x8de5476c4c95b6d5, 0x67eb88a9939d83c0, 0x9a793e85b519952d, 0x11988fe592cae3aa}
 
+	// fqOneThird is 1/3 in the Montgomery domain.
+	fqOneThird = &fq{0x4e02555555561c71, 0x0dc400030ce6aaab, 0xb9e369ddc0631701, 0xc03efa7472742996, 0xa614ce0162fa175e, 0x18a82b8824803b42}
+
 	// r is the order of the groups.
 	r, _ = bigFromBase10("52435875175126190479447740508185965837690552500527637822603658699938581184513")
 
+	// r64 is r as 64 bit words.
+	r64 = [4]uint64{0xffffffff00000001, 0x53bda402fffe5bfe, 0x3339d80809a1d805, 0x73eda753299d7d48}
+
+	// rMinusTwo is the value by which to exponentiate r-order field elements to
+	// calculate their inverse.
+	rMinusTwo = []uint64{0xfffffffeffffffff, 0x53bda402fffe5bfe, 0x3339d80809a1d805, 0x73eda753299d7d48}
+
+	// rMinusOneOddOverTwo is (t-1)/2, where r-1 = 2^frTwoAdicity t with t odd, the
+	// value by which to exponentiate r-order field elements to start the
+	// Tonelli-Shanks square root.
+	rMinusOneOddOverTwo = []uint64{0x7fff2dff7fffffff, 0x04d0ec02a9ded201, 0x94cebea4199cec04, 0x0000000039f6d3a9}
+
+	// frR2 is the value by which to multiply r-order field elements to map them
+	// to the Montgomery domain.
+	frR2 = &Fr{0xc999e990f3f29c6d, 0x2b6cedcb87925c23, 0x05d314967254398f, 0x0748d9d99f59ff11}
+
+	// frOne is 1 in the Montgomery domain.
+	frOne = &Fr{0x00000001fffffffe, 0x5884b7fa00034802, 0x998c4fefecbc4ff5, 0x1824b159acc5056f}
+
+	// frWord is 2^64 in the Montgomery domain.
+	frWord = &Fr{0xc98da28e0121c884, 0xe6f4f4a0c7363c67, 0xb2d6ebc4e92e7df1, 0x19ae57949d26242a}
+
+	// frRootOfUnity is 7^t in the Montgomery domain, a primitive 2^frTwoAdicity-th
+	// root of unity, where 7 is the smallest non-square of the scalar field.
+	frRootOfUnity = &Fr{0xb9b58d8c5f0e466a, 0x5b1b4c801819d7ec, 0x0af53ae352a31e64, 0x5bf3adda19e9b27b}
+
 	// g1X is the x-coordinate of G1's generator.
 	g1X = &fq{6679831729115696150, 8653662730902241269, 1535610680227111361, 17342916647841752903, 17135755455211762752, 1297449291367578485}
 
@@ -45,9 +89,19 @@ var (
 	// g1Cofactor is the cofactor by which to multiply points to map them to G1. (on to the r-torsion). h = (x - 1)2 / 3
 	g1Cofactor, _ = bigFromBase10("76329603384216526031706109802092473003")
 
+	// g1EffectiveCofactor is the scalar by which to multiply points to clear the
+	// cofactor when hashing to G1, h_eff = 1 - x.
+	// See https://www.rfc-editor.org/rfc/rfc9380.html#section-8.8.1.
+	g1EffectiveCofactor = new(big.Int).SetUint64(0xd201000000010001)
+
 	// g2Cofactor is the cofactor by which to multiply points to map the to G2.
 	g2Cofactor, _ = bigFromBase10("305502333931268344200999753193121504214466019254188142667664032982267604182971884026507427359259977847832272839041616661285803823378372096355777062779109")
 
+	// g2EffectiveCofactor is the scalar by which to multiply points to clear the
+	// cofactor when hashing to G2, h_eff = 3(x² - 1) h2 with h2 = g2Cofactor.
+	// See https://www.rfc-editor.org/rfc/rfc9380.html#section-8.8.2.
+	g2EffectiveCofactor, _ = bigFromBase10("209869847837335686905080341498658477663839067235703451875306851526599783796572738804459333109033834234622528588876978987822447936461846631641690358257586228683615991308971558879306463436166481")
+
 	// frobFq2C1 contains the value by which to multiply c1 to calculate the frobenius for a certain power.
 	frobFq2C1 = [2]*fq{
 		&fq{0x760900000002fffd, 0xebf4000bc40c0002, 0x5f48985753c758ba, 0x77ce585370525745, 0x5c071a97a256ec6d, 0x15f65ec3fa80e493},
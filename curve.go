@@ -1,140 +1,186 @@
 package bls12
 
 import (
+	"errors"
 	"math/big"
 )
 
+// See https://github.com/zkcrypto/pairing/tree/master/src/bls12_381#serialization.
 const (
 	compressedFormMask  uint8 = 1 << 7
 	pointAtInfinityMask uint8 = 1 << 6
+	largestYMask        uint8 = 1 << 5
+	flagsMask                 = compressedFormMask | pointAtInfinityMask | largestYMask
+)
+
+// Errors returned when decoding points.
+var (
+	// ErrInvalidLength is returned when the encoding has the wrong length.
+	ErrInvalidLength = errors.New("bls12: invalid point encoding length")
+
+	// ErrInvalidFlags is returned when the flag bits do not match the form
+	// of the encoding.
+	ErrInvalidFlags = errors.New("bls12: invalid point encoding flags")
+
+	// ErrInvalidInfinity is returned when the point at infinity is encoded
+	// with non-zero bits other than the compression and infinity flags.
+	ErrInvalidInfinity = errors.New("bls12: invalid encoding of the point at infinity")
+
+	// ErrNonCanonical is returned when a coordinate is not reduced modulo q.
+	ErrNonCanonical = errors.New("bls12: non-canonical field element")
+
+	// ErrNotOnCurve is returned when the point does not satisfy the curve
+	// equation.
+	ErrNotOnCurve = errors.New("bls12: point is not on the curve")
+
+	// ErrNotInSubgroup is returned when the point is not in the subgroup of
+	// order r.
+	ErrNotInSubgroup = errors.New("bls12: point is not in the subgroup")
 )
 
 var (
 	fqCurveB, _                   = new(fq).SetString("4")
 	fqCurveBPlusOne, _            = new(fq).SetString("5")
+	fqCurveB3, _                  = new(fq).SetString("12")
 	fqSqrtNegThree, _             = new(fq).SetString("1586958781458431025242759403266842894121773480562120986020912974854563298150952611241517463240701")
 	fqHalfSqrtNegThreeMinusOne, _ = new(fq).SetString("793479390729215512621379701633421447060886740281060493010456487427281649075476305620758731620350")
 
+	// fqBeta is the primitive cube root of unity such that sigma(x, y) =
+	// (beta x, y) acts on G1 as the multiplication by -x². It is equal to
+	// (sqrt(-3) - 1) / 2.
+	fqBeta = fqHalfSqrtNegThreeMinusOne
+
+	// fqIsoA and fqIsoB are the coefficients of E1´: y²=x³+A´x+B´, the curve
+	// that is 11-isogenous to E1.
+	fqIsoA, _ = new(fq).SetString("12190336318893619529228877361869031420615612348429846051986726275283378313155663745811710833465465981901188123677")
+	fqIsoB, _ = new(fq).SetString("2906670324641927570491258158026293881577086121416628140204402091718288198173574630967936031029026176254968826637280")
+
+	// fqSWUZ is the non-square Z used by the simplified SWU map for E1´.
+	fqSWUZ, _ = new(fq).SetString("11")
+
+	// fqSWUC2 is sqrt(-Z³).
+	fqSWUC2, _ = new(fq).SetString("590728492726997966099618626120482682095437733689734941576187760067601492637096712773899690116980580577616657575925")
+
 	iso11XNum = []*fq{
 		// 2712959285290305970661081772124144179193819192423276218370281158706191519995889425075952244140278856085036081760695
-		&fq{},
+		&fq{0x4d18b6f3af00131c, 0x19fa219793fee28c, 0x3f2885f1467f19ae, 0x23dcea34f2ffb304, 0xd15b58d2ffc00054, 0x913be200a20bef4},
 		// 3564859427549639835253027846704205725951033235539816243131874237388832081954622352624080767121604606753339903542203
-		&fq{},
+		&fq{0x898985385cdbbd8b, 0x3c79e43cc7d966aa, 0x1597e193f4cd233a, 0x8637ef1e4d6623ad, 0x11b22deed20d827b, 0x7097bc5998784ad},
 		// 2051387046688339481714726479723076305756384619135044672831882917686431912682625619320120082313093891743187631791280
-		&fq{},
+		&fq{0xa542583a480b664b, 0xfc7169c026e568c6, 0x5ba2ef314ed8b5a6, 0x5b5491c05102f0e7, 0xdf6e99707d2a0079, 0x784151ed7605524},
 		// 3612713941521031012780325893181011392520079402153354595775735142359240110423346445050803899623018402874731133626465
-		&fq{},
+		&fq{0x494e212870f72741, 0xab9be52fbda43021, 0x26f5577994e34c3d, 0x49dfee82aefbd60, 0x65dadd7828505289, 0xe93d431ea011aeb},
 		// 2247053637822768981792833880270996398470828564809439728372634811976089874056583714987807553397615562273407692740057
-		&fq{},
+		&fq{0x90ee774bd6a74d45, 0x7ada1c8a41bfb185, 0xf1a8953b325f464, 0x104c24211be4805c, 0x169139d319ea7a8f, 0x9f20ead8e532bf6},
 		// 3415427104483187489859740871640064348492611444552862448295571438270821994900526625562705192993481400731539293415811
-		&fq{},
+		&fq{0x6ddd93e2f43626b7, 0xa5482c9aa1ccd7bd, 0x143245631883f4bd, 0x2e0a94ccf77ec0db, 0xb0282d480e56489f, 0x18f4bfcbb4368929},
 		// 2067521456483432583860405634125513059912765526223015704616050604591207046392807563217109432457129564962571408764292
-		&fq{},
+		&fq{0x23c5f0c953402dfd, 0x7a43ff6958ce4fe9, 0x2c390d3d2da5df63, 0xd0df5c98e1f9d70f, 0xffd89869a572b297, 0x1277ffc72f25e8fe},
 		// 3650721292069012982822225637849018828271936405382082649291891245623305084633066170122780668657208923883092359301262
-		&fq{},
+		&fq{0x79f4f0490f06a8a6, 0x85f894a88030fd81, 0x12da3054b18b6410, 0xe2a57f6505880d65, 0xbba074f260e400f1, 0x8b76279f621d028},
 		// 1239271775787030039269460763652455868148971086016832054354147730155061349388626624328773377658494412538595239256855
-		&fq{},
+		&fq{0xe67245ba78d5b00b, 0x8456ba9a1f186475, 0x7888bff6e6b33bb4, 0xe21585b9a30f86cb, 0x5a69cdcef55feee, 0x9e699dd9adfa5ac},
 		// 3479374185711034293956731583912244564891370843071137483962415222733470401948838363051960066766720884717833231600798
-		&fq{},
+		&fq{0xde5c357bff57107, 0xa0db4ae6b1a10b2, 0xe256bb67b3b3cd8d, 0x8ad456574e9db24f, 0x443915f50fd4179, 0x98c4bf7de8b6375},
 		// 2492756312273161536685660027440158956721981129429869601638362407515627529461742974364729223659746272460004902959995
-		&fq{},
+		&fq{0xe6b0617e7dd929c7, 0xfe6e37d442537375, 0x1dafdeda137a489e, 0xe4efd1ad3f767ceb, 0x4a51d8667f0fe1cf, 0x54fdf4bbf1d821c},
 		// 1058488477413994682556770863004536636444795456512795473806825292198091015005841418695586811009326456605062948114985
-		&fq{},
+		&fq{0x72db2a50658d767b, 0x8abf91faa257b3d5, 0xe969d6833764ab47, 0x464170142a1009eb, 0xb14f01aadb30be2f, 0x18ae6a856f40715d},
 	}
 
 	iso11XDen = []*fq{
 		// 1353092447850172218905095041059784486169131709710991428415161466575141675351394082965234118340787683181925558786844
-		&fq{},
-		//2822220997908397120956501031591772354860004534930174057793539372552395729721474912921980407622851861692773516917759
-		&fq{},
+		&fq{0xb962a077fdb0f945, 0xa6a9740fefda13a0, 0xc14d568c3ed6c544, 0xb43fc37b908b133e, 0x9c0b3ac929599016, 0x165aa6c93ad115f},
+		// 2822220997908397120956501031591772354860004534930174057793539372552395729721474912921980407622851861692773516917759
+		&fq{0x23279a3ba506c1d9, 0x92cfca0a9465176a, 0x3b294ab13755f0ff, 0x116dda1c5070ae93, 0xed4530924cec2045, 0x83383d6ed81f1ce},
 		// 1717937747208385987946072944131378949849282930538642983149296304709633281382731764122371874602115081850953846504985
-		&fq{},
+		&fq{0x9885c2a6449fecfc, 0x4a2b54ccd37733f0, 0x17da9ffd8738c142, 0xa0fba72732b3fafd, 0xff364f36e54b6812, 0xf29c13c660523e2},
 		// 501624051089734157816582944025690868317536915684467868346388760435016044027032505306995281054569109955275640941784
-		&fq{},
+		&fq{0xe349cc118278f041, 0xd487228f2f3204fb, 0xc9d325849ade5150, 0x43a92bd69c15c2df, 0x1c2c7844bc417be4, 0x12025184f407440c},
 		// 3025903087998593826923738290305187197829899948335370692927241015584233559365859980023579293766193297662657497834014
-		&fq{},
+		&fq{0x587f65ae6acb057b, 0x1444ef325140201f, 0xfbf995e71270da49, 0xccda066072436a42, 0x7408904f0f186bb2, 0x13b93c63edf6c015},
 		// 2224140216975189437834161136818943039444741035168992629437640302964164227138031844090123490881551522278632040105125
-		&fq{},
+		&fq{0xfb918622cd141920, 0x4a4c64423ecaddb4, 0xbeb232927f7fb26, 0x30f94df6f83a3dc2, 0xaeedd424d780f388, 0x6cc402dd594bbeb},
 		// 1146414465848284837484508420047674663876992808692209238763293935905506532411661921697047880549716175045414621825594
-		&fq{},
+		&fq{0xd41f761151b23f8f, 0x32a92465435719b3, 0x64f436e888c62cb9, 0xdf70a9a1f757c6e4, 0x6933a38d5b594c81, 0xc6f7f7237b46606},
 		// 3179090966864399634396993677377903383656908036827452986467581478509513058347781039562481806409014718357094150199902
-		&fq{},
+		&fq{0x693c08747876c8f7, 0x22c9850bf9cf80f0, 0x8e9071dab950c124, 0x89bc62d61c7baf23, 0xbc6be2d8dad57c23, 0x17916987aa14a122},
 		// 1549317016540628014674302140786462938410429359529923207442151939696344988707002602944342203885692366490121021806145
-		&fq{},
+		&fq{0x1be3ff439c1316fd, 0x9965243a7571dfa7, 0xc7f7f62962f5cd81, 0x32c6aa9af394361c, 0xbbc2ee18e1c227f4, 0xc102cbac531bb34},
 		// 1442797143427491432630626390066422021593505165588630398337491100088557278058060064930663878153124164818522816175370
-		&fq{},
+		&fq{0x997614c97bacbf07, 0x61f86372b99192c0, 0x5b8c95fc14353fc3, 0xca2b066c2a87492f, 0x16178f5bbf698711, 0x12a6dcd7f0f4e0e8},
 		// 1
-		&fq{},
+		&fq{0x760900000002fffd, 0xebf4000bc40c0002, 0x5f48985753c758ba, 0x77ce585370525745, 0x5c071a97a256ec6d, 0x15f65ec3fa80e493},
 	}
 
 	iso11YNum = []*fq{
 		// 1393399195776646641963150658816615410692049723305861307490980409834842911816308830479576739332720113414154429643571
-		&fq{},
+		&fq{0x2b567ff3e2837267, 0x1d4d9e57b958a767, 0xce028fea04bd7373, 0xcc31a30a0b6cd3df, 0x7d7b18a682692693, 0xd300744d42a0310},
 		// 2968610969752762946134106091152102846225411740689724909058016729455736597929366401532929068084731548131227395540630
-		&fq{},
+		&fq{0x99c2555fa542493f, 0xfe7f53cc4874f878, 0x5df0608b8f97608a, 0x14e03832052b49c8, 0x706326a6957dd5a4, 0xa8dadd9c2414555},
 		// 122933100683284845219599644396874530871261396084070222155796123161881094323788483360414289333111221370374027338230
-		&fq{},
+		&fq{0x13d942922a5cf63a, 0x357e33e36e261e7d, 0xcf05a27c8456088d, 0xbd1de7ba50f0, 0x83d0c7532f8c1fde, 0x13f70bf38bbf2905},
 		// 303251954782077855462083823228569901064301365507057490567314302006681283228886645653148231378803311079384246777035
-		&fq{},
+		&fq{0x5c57fd95bfafbdbb, 0x28a359a65e541707, 0x3983ceb4f6360b6d, 0xafe19ff6f97e6d53, 0xb3468f4550192bf7, 0xbb6cde49d8ba257},
 		// 1353972356724735644398279028378555627591260676383150667237975415318226973994509601413730187583692624416197017403099
-		&fq{},
+		&fq{0x590b62c7ff8a513f, 0x314b4ce372cacefd, 0x6bef32ce94b8a800, 0x6ddf84a095713d5f, 0x64eace4cb0982191, 0x386213c651b888d},
 		// 3443977503653895028417260979421240655844034880950251104724609885224259484262346958661845148165419691583810082940400
-		&fq{},
+		&fq{0xa5310a31111bbcdd, 0xa14ac0f5da148982, 0xf9ad9cc95423d2e9, 0xaa6ec095283ee4a7, 0xcf5b1f022e1c9107, 0x1fddf5aed881793},
 		// 718493410301850496156792713845282235942975872282052335612908458061560958159410402177452633054233549648465863759602
-		&fq{},
+		&fq{0x65a572b0d7a7d950, 0xe25c2d8183473a19, 0xc2fcebe7cb877dbd, 0x5b2d36c769a89b0, 0xba12961be86e9efb, 0x7eb1b29c1dfde1f},
 		// 1466864076415884313141727877156167508644960317046160398342634861648153052436926062434809922037623519108138661903145
-		&fq{},
+		&fq{0x93e09572f7c4cd24, 0x364e929076795091, 0x8569467e68af51b5, 0xa47da89439f5340f, 0xf4fa918082e44d64, 0xad52ba3e6695a79},
 		// 1536886493137106337339531461344158973554574987550750910027365237255347020572858445054025958480906372033954157667719
-		&fq{},
+		&fq{0x911429844e0d5f54, 0xd03f51a3516bb233, 0x3d587e5640536e66, 0xfa86d2a3a9a73482, 0xa90ed5adf1ed5537, 0x149c9c326a5e7393},
 		// 2171468288973248519912068884667133903101171670397991979582205855298465414047741472281361964966463442016062407908400
-		&fq{},
+		&fq{0x462bbeb03c12921a, 0xdc9af5fa0a274a17, 0x9a558ebde836ebed, 0x649ef8f11a4fae46, 0x8100e1652b3cdc62, 0x1862bd62c291dacb},
 		// 3915937073730221072189646057898966011292434045388986394373682715266664498392389619761133407846638689998746172899634
-		&fq{},
+		&fq{0x5c9b8ca89f12c26, 0x194160fa9b9ac4f, 0x6a643d5a6879fa2c, 0x14665bdd8846e19d, 0xbb1d0d53af3ff6bf, 0x12c7e1c3b28962e5},
 		// 3802409194827407598156407709510350851173404795262202653149767739163117554648574333789388883640862266596657730112910
-		&fq{},
+		&fq{0xb55ebf900b8a3e17, 0xfedc77ec1a9201c4, 0x1f07db10ea1a4df4, 0xdfbd15dc41a594d, 0x389547f2334a5391, 0x2419f98165871a4},
 		// 1707589313757812493102695021134258021969283151093981498394095062397393499601961942449581422761005023512037430861560
-		&fq{},
+		&fq{0xb416af000745fc20, 0x8e563e9d1ea6d0f5, 0x7c763e17763a0652, 0x1458ef0159ebbef, 0x8346fe421f96bb13, 0xd2d7b829ce324d2},
 		// 349697005987545415860583335313370109325490073856352967581197273584891698473628451945217286148025358795756956811571
-		&fq{},
+		&fq{0x93096bb538d64615, 0x6f2a2619951d823a, 0x8f66b3ea59514fa4, 0xf563e63704f7092f, 0x724b136c4cf2d9fa, 0x46959cfcfd0bf49},
 		// 885704436476567581377743161796735879083481447641210566405057346859953524538988296201011389016649354976986251207243
-		&fq{},
+		&fq{0xea748d4b6e405346, 0x91e9079c2c02d58f, 0x41064965946d9b59, 0xa06731f1d2bbe1ee, 0x7f897e267a33f1b, 0x1017290919210e5f},
 		// 3370924952219000111210625390420697640496067348723987858345031683392215988129398381698161406651860675722373763741188
-		&fq{},
+		&fq{0x872aa6c17d985097, 0xeecc53161264562a, 0x7afe37afff55002, 0x54759078e5be6838, 0xc4b92d15db8acca8, 0x106d87d1b51d13b9},
 	}
 
 	iso11YDen = []*fq{
 		// 3396434800020507717552209507749485772788165484415495716688989613875369612529138640646200921379825018840894888371137
-		&fq{},
+		&fq{0xeb6c359d47e52b1c, 0x18ef5f8a10634d60, 0xddfa71a0889d5b7e, 0x723e71dcc5fc1323, 0x52f45700b70d5c69, 0xa8b981ee47691f1},
 		// 3907278185868397906991868466757978732688957419873771881240086730384895060595583602347317992689443299391009456758845
-		&fq{},
+		&fq{0x616a3c4f5535b9fb, 0x6f5f037395dbd911, 0xf25f4cc5e35c65da, 0x3e50dffea3c62658, 0x6a33dca523560776, 0xfadeff77b6bfe3e},
 		// 854914566454823955479427412036002165304466268547334760894270240966182605542146252771872707010378658178126128834546
-		&fq{},
+		&fq{0x2be9b66df470059c, 0x24a2c159a3d36742, 0x115dbe7ad10c2a37, 0xb6634a652ee5884d, 0x4fe8bb2b8d81af4, 0x1c2a7a256fe9c41},
 		// 3496628876382137961119423566187258795236027183112131017519536056628828830323846696121917502443333849318934945158166
-		&fq{},
+		&fq{0xf27bf8ef3b75a386, 0x898b367476c9073f, 0x24482e6b8c2f4e5f, 0xc8e0bbd6fe110806, 0x59b0c17f7631448a, 0x11037cd58b3dbfbd},
 		// 1828256966233331991927609917644344011503610008134915752990581590799656305331275863706710232159635159092657073225757
-		&fq{},
+		&fq{0x31c7912ea267eec6, 0x1dbf6f1c5fcdb700, 0xd30d4fe3ba86fdb1, 0x3cae528fbee9a2a4, 0xb1cce69b6aa9ad9a, 0x44393bb632d94fb},
 		// 1362317127649143894542621413133849052553333099883364300946623208643344298804722863920546222860227051989127113848748
-		&fq{},
+		&fq{0xc66ef6efeeb5c7e8, 0x9824c289dd72bb55, 0x71b1a4d2f119981d, 0x104fc1aafb0919cc, 0xe49df01d942a628, 0x96c3a09773272d4},
 		// 3443845896188810583748698342858554856823966611538932245284665132724280883115455093457486044009395063504744802318172
-		&fq{},
+		&fq{0x9abc11eb5fadeff4, 0x32dca50a885728f0, 0xfb1fa3721569734c, 0xc4b76271ea6506b3, 0xd466a75599ce728e, 0xc81d4645f4cb6ed},
 		// 3484671274283470572728732863557945897902920439975203610275006103818288159899345245633896492713412187296754791689945
-		&fq{},
+		&fq{0x4199f10e5b8be45b, 0xda64e495b1e87930, 0xcb353efe9b33e4ff, 0x9e9efb24aa6424c6, 0xf08d33680a237465, 0xd3378023e4c7406},
 		// 3755735109429418587065437067067640634211015783636675372165599470771975919172394156249639331555277748466603540045130
-		&fq{},
+		&fq{0x7eb4ae92ec74d3a5, 0xc341b4aa9fac3497, 0x5be603899e907687, 0x3bfd9cca75cbdeb, 0x564c2935a96bfa93, 0xef3c33371e2fdb5},
 		// 3459661102222301807083870307127272890283709299202626530836335779816726101522661683404130556379097384249447658110805
-		&fq{},
+		&fq{0x7ee91fd449f6ac2e, 0xe5d5bd5cb9357a30, 0x773a8ca5196b1380, 0xd0fda172174ed023, 0x6cb95e0fa776aead, 0xd22d5a40cec7cff},
 		// 742483168411032072323733249644347333168432665415341249073150659015707795549260947228694495111018381111866512337576
-		&fq{},
+		&fq{0xf727e09285fd8519, 0xdc9d55a83017897b, 0x7549d8bd057894ae, 0x178419613d90d8f8, 0xfce95ebdeb5b490a, 0x467ffaef23fc49e},
 		// 1662231279858095762833829698537304807741442669992646287950513237989158777254081548205552083108208170765474149568658
-		&fq{},
+		&fq{0xc1769e6a7c385f1b, 0x79bc930deac01c03, 0x5461c75a23ede3b5, 0x6e20829e5c230c45, 0x828e0f1e772a53cd, 0x116aefa749127bff},
 		// 1668238650112823419388205992952852912407572045257706138925379268508860023191233729074751042562151098884528280913356
-		&fq{},
+		&fq{0x101c10bf2744c10a, 0xbbf18d053a6a3154, 0xa0ecf39ef026f602, 0xfc009d4996dc5153, 0xb9000209d5bd08d3, 0x189e5fe4470cd73c},
 		// 369162719928976119195087327055926326601627748362769544198813069133429557026740823593067700396825489145575282378487
-		&fq{},
+		&fq{0x7ebd546ca1575ed2, 0xe47d5a981d081b55, 0x57b2b625b6d4ca21, 0xb0a1ba04228520cc, 0x98738983c2107ff3, 0x13dddbc4799d81d6},
 		// 2164195715141237148945939585099633032390257748382945597506236650132835917087090097395995817229686247227784224263055
-		&fq{},
+		&fq{0x9319f2e39834935, 0x39e952cbdb05c21, 0x55ba77a9a2f76493, 0xfd04e3dfc6086467, 0xfb95832e7d78742e, 0xef9c24eccaf5e0e},
 		// 1
-		&fq{},
+		&fq{0x760900000002fffd, 0xebf4000bc40c0002, 0x5f48985753c758ba, 0x77ce585370525745, 0x5c071a97a256ec6d, 0x15f65ec3fa80e493},
 	}
 
 	// Values taken from the execution of https://eprint.iacr.org/2019/403.pdf - A The isogeny maps.
@@ -163,6 +209,67 @@ func (a *curvePoint) IsInfinity() bool {
 	return a.z == fq{}
 }
 
+// IsOnCurve reports whether a satisfies the curve equation, Y² = X³ + 4Z⁶ in
+// jacobian coordinates.
+func (a *curvePoint) IsOnCurve() bool {
+	if a.IsInfinity() {
+		return true
+	}
+
+	lhs, rhs, t := new(fq), new(fq), new(fq)
+	fqSqr(lhs, &a.y)
+	fqSqr(rhs, &a.x)
+	fqMul(rhs, rhs, &a.x)
+	fqSqr(t, &a.z)
+	fqMul(t, t, &a.z)
+	fqSqr(t, t)
+	fqMul(t, t, fqCurveB)
+	fqAdd(rhs, rhs, t)
+
+	return *lhs == *rhs
+}
+
+// IsInSubgroup reports whether a is in the subgroup of order r. a is in G1 if
+// and only if sigma(a) = -[x²]a.
+// See https://eprint.iacr.org/2021/1130.pdf.
+func (a *curvePoint) IsInSubgroup() bool {
+	p := new(curvePoint).mulByU(a)
+	p.mulByU(p)
+	fqNeg(&p.y, &p.y)
+	return new(curvePoint).Sigma(a).isEquivalent(p)
+}
+
+// Sigma sets c to the endomorphism (x, y) -> (beta x, y) of a and returns c.
+func (c *curvePoint) Sigma(a *curvePoint) *curvePoint {
+	fqMul(&c.x, &a.x, fqBeta)
+	c.y, c.z = a.y, a.z
+	return c
+}
+
+// isEquivalent reports whether a and b represent the same point, regardless of
+// their jacobian coordinates.
+func (a *curvePoint) isEquivalent(b *curvePoint) bool {
+	if a.IsInfinity() || b.IsInfinity() {
+		return a.IsInfinity() == b.IsInfinity()
+	}
+
+	// X1 Z2² = X2 Z1² and Y1 Z2³ = Y2 Z1³
+	z1z1, z2z2, t0, t1 := new(fq), new(fq), new(fq), new(fq)
+	fqSqr(z1z1, &a.z)
+	fqSqr(z2z2, &b.z)
+	fqMul(t0, &a.x, z2z2)
+	fqMul(t1, &b.x, z1z1)
+	if *t0 != *t1 {
+		return false
+	}
+	fqMul(t0, &a.y, z2z2)
+	fqMul(t0, t0, &b.z)
+	fqMul(t1, &b.y, z1z1)
+	fqMul(t1, t1, &a.z)
+
+	return *t0 == *t1
+}
+
 // Add sets c to the sum a+b and returns c.
 func (c *curvePoint) Add(a, b *curvePoint) *curvePoint {
 	if a.IsInfinity() {
@@ -172,15 +279,10 @@ func (c *curvePoint) Add(a, b *curvePoint) *curvePoint {
 		return c.Set(a)
 	}
 
-	// faster than Add
-	if a.Equal(b) {
-		return c.Double(a)
-	}
-
 	// See https://hyperelliptic.org/EFD/g1p/auto-shortw-jacobian-0.html#addition-add-2007-bl
 	z1z1, z2z2 := new(fq), new(fq)
-	fqMul(z1z1, &a.z, &a.z)
-	fqMul(z2z2, &b.z, &b.z)
+	fqSqr(z1z1, &a.z)
+	fqSqr(z2z2, &b.z)
 
 	u1, u2 := new(fq), new(fq)
 	fqMul(u1, &a.x, z2z2)
@@ -194,17 +296,26 @@ func (c *curvePoint) Add(a, b *curvePoint) *curvePoint {
 
 	h, i, j, r, v := new(fq), new(fq), new(fq), new(fq), new(fq)
 	fqSub(h, u2, u1)
+	fqSub(r, s2, s1)
+
+	// a and b have the same x coordinate: a = ±b.
+	if *h == (fq{}) {
+		if *r == (fq{}) {
+			return c.Double(a)
+		}
+		return c.Set(&curvePoint{})
+	}
+
 	fqAdd(i, h, h)
-	fqMul(i, i, i)
+	fqSqr(i, i)
 	fqMul(j, h, i)
-	fqSub(r, s2, s1)
 	fqAdd(r, r, r)
 	fqMul(v, u1, i)
 
 	p, t0, t1 := new(curvePoint), new(fq), new(fq)
 	fqAdd(t0, v, v)
 	fqAdd(t0, t0, j)
-	fqMul(&p.x, r, r)
+	fqSqr(&p.x, r)
 	fqSub(&p.x, &p.x, t0)
 
 	fqAdd(t0, s1, s1)
@@ -214,7 +325,7 @@ func (c *curvePoint) Add(a, b *curvePoint) *curvePoint {
 	fqSub(&p.y, t1, t0)
 
 	fqAdd(&p.z, &a.z, &b.z)
-	fqMul(&p.z, &p.z, &p.z)
+	fqSqr(&p.z, &p.z)
 	fqAdd(t0, z1z1, z2z2)
 	fqSub(&p.z, &p.z, t0)
 	fqMul(&p.z, &p.z, h)
@@ -222,19 +333,74 @@ func (c *curvePoint) Add(a, b *curvePoint) *curvePoint {
 	return c.Set(p)
 }
 
+// AddMixed sets c to the sum a+b and returns c.
+// See https://hyperelliptic.org/EFD/g1p/auto-shortw-jacobian-0.html#addition-madd-2007-bl
+func (c *curvePoint) AddMixed(a *curvePoint, b *curvePointAffine) *curvePoint {
+	if a.IsInfinity() {
+		c.x, c.y = b.x, b.y
+		c.z.SetUint64(1)
+		return c
+	}
+
+	z1z1, u2, s2 := new(fq), new(fq), new(fq)
+	fqSqr(z1z1, &a.z)
+	fqMul(u2, &b.x, z1z1)
+	fqMul(s2, &b.y, &a.z)
+	fqMul(s2, s2, z1z1)
+
+	h, r := new(fq), new(fq)
+	fqSub(h, u2, &a.x)
+	fqSub(r, s2, &a.y)
+
+	// a and b have the same x coordinate: a = ±b.
+	if *h == (fq{}) {
+		if *r == (fq{}) {
+			return c.Double(a)
+		}
+		return c.Set(&curvePoint{})
+	}
+
+	hh, i, j, v := new(fq), new(fq), new(fq), new(fq)
+	fqSqr(hh, h)
+	fqAdd(i, hh, hh)
+	fqAdd(i, i, i)
+	fqMul(j, h, i)
+	fqAdd(r, r, r)
+	fqMul(v, &a.x, i)
+
+	p, t0 := new(curvePoint), new(fq)
+	fqSqr(&p.x, r)
+	fqSub(&p.x, &p.x, j)
+	fqSub(&p.x, &p.x, v)
+	fqSub(&p.x, &p.x, v)
+
+	fqSub(t0, v, &p.x)
+	fqMul(&p.y, r, t0)
+	fqMul(t0, &a.y, j)
+	fqAdd(t0, t0, t0)
+	fqSub(&p.y, &p.y, t0)
+
+	fqAdd(&p.z, &a.z, h)
+	fqSqr(&p.z, &p.z)
+	fqSub(&p.z, &p.z, z1z1)
+	fqSub(&p.z, &p.z, hh)
+
+	return c.Set(p)
+}
+
 // Double sets c to the sum a+a and returns c.
 // See http://www.hyperelliptic.org/EFD/g1p/auto-shortw-jacobian-0.html#doubling-dbl-2009-l
 func (c *curvePoint) Double(a *curvePoint) *curvePoint {
 	d, e, f, g, h, i := new(fq), new(fq), new(fq), new(fq), new(fq), new(fq)
-	fqMul(d, &a.x, &a.x)
-	fqMul(e, &a.y, &a.y)
-	fqMul(f, e, e)
+	fqSqr(d, &a.x)
+	fqSqr(e, &a.y)
+	fqSqr(f, e)
 	fqMul(g, &a.x, e)
 	fqAdd(g, g, g)
 	fqAdd(g, g, g)
 	fqAdd(h, d, d)
 	fqAdd(h, h, d)
-	fqMul(i, h, h)
+	fqSqr(i, h)
 
 	p, t0 := new(curvePoint), new(fq)
 	fqAdd(&p.x, g, g)
@@ -265,22 +431,169 @@ func (c *curvePoint) ScalarMult(a *curvePoint, b *big.Int) *curvePoint {
 	return c.Set(p)
 }
 
+// ScalarMultGLV sets c to k*a and returns c. a must be in G1 since
+// ScalarMultGLV splits k in two halves of half the size with the endomorphism
+// sigma, which acts on G1 as the multiplication by -x², and computes both
+// halves at once with interleaved width-wnafWidth non-adjacent forms.
+// See https://www.iacr.org/archive/crypto2001/21390189.pdf.
+func (c *curvePoint) ScalarMultGLV(a *curvePoint, k *[scalarWords]uint64) *curvePoint {
+	k0, k1 := glvDecompose(k)
+	t0, t1 := curveGLVTables(a)
+
+	return c.interleavedWNAF(
+		[]*[wnafTableSize]curvePoint{t0, t1},
+		[][]int8{wnaf(k0, wnafWidth), wnaf(k1, wnafWidth)},
+	)
+}
+
+// DoubleScalarMultGLV sets c to ka*p + kb*q and returns c. p and q must be in G1.
+// DoubleScalarMultGLV splits both scalars as in ScalarMultGLV and shares the
+// doublings of the four halves.
+func (c *curvePoint) DoubleScalarMultGLV(ka *[scalarWords]uint64, p *curvePoint, kb *[scalarWords]uint64, q *curvePoint) *curvePoint {
+	ka0, ka1 := glvDecompose(ka)
+	kb0, kb1 := glvDecompose(kb)
+	tp0, tp1 := curveGLVTables(p)
+	tq0, tq1 := curveGLVTables(q)
+
+	return c.interleavedWNAF(
+		[]*[wnafTableSize]curvePoint{tp0, tp1, tq0, tq1},
+		[][]int8{wnaf(ka0, wnafWidth), wnaf(ka1, wnafWidth), wnaf(kb0, wnafWidth), wnaf(kb1, wnafWidth)},
+	)
+}
+
+// curveGLVTables returns the odd multiples (2i+1)*a and (2i+1)*x²*a =
+// -sigma((2i+1)*a) used by the GLV method.
+func curveGLVTables(a *curvePoint) (t0, t1 *[wnafTableSize]curvePoint) {
+	t0, t1 = new([wnafTableSize]curvePoint), new([wnafTableSize]curvePoint)
+	t0[0].Set(a)
+	double := new(curvePoint).Double(a)
+	for i := 1; i < wnafTableSize; i++ {
+		t0[i].Add(&t0[i-1], double)
+	}
+	for i := range t1 {
+		t1[i].Sigma(&t0[i]).Neg(&t1[i])
+	}
+	return t0, t1
+}
+
+// interleavedWNAF sets c to the sum of nafs[j]*tables[j][0] and returns c,
+// where tables[j] holds the odd multiples of a point. The non-adjacent forms
+// share a single chain of doublings.
+// See https://link.springer.com/chapter/10.1007/978-3-540-30564-4_8.
+func (c *curvePoint) interleavedWNAF(tables []*[wnafTableSize]curvePoint, nafs [][]int8) *curvePoint {
+	n := 0
+	for _, naf := range nafs {
+		if len(naf) > n {
+			n = len(naf)
+		}
+	}
+
+	p, t := new(curvePoint), new(curvePoint)
+	for i := n - 1; i >= 0; i-- {
+		p.Double(p)
+		for j, naf := range nafs {
+			if i >= len(naf) || naf[i] == 0 {
+				continue
+			}
+			if d := naf[i]; d > 0 {
+				p.Add(p, &tables[j][d/2])
+			} else {
+				p.Add(p, t.Neg(&tables[j][-d/2]))
+			}
+		}
+	}
+
+	return c.Set(p)
+}
+
+// ScalarMultCT sets c to k*a and returns c, in constant time with respect to
+// k. a must be in G1 since k is split in two halves with
+// the endomorphism sigma as in ScalarMultGLV. ScalarMultCT uses a fixed window
+// of ctWindowBits over both halves, constant-time table lookups and complete
+// addition formulas in homogeneous projective coordinates.
+func (c *curvePoint) ScalarMultCT(a *curvePoint, k *[scalarWords]uint64) *curvePoint {
+	k0, k1 := glvDecompose(k)
+	t0, t1 := curveGLVTablesCT(a)
+
+	return c.fixedWindowCT(
+		[]*[ctWindowSize]curvePointProj{t0, t1},
+		[]*[scalarWords]uint64{&k0, &k1},
+		glvWindows,
+	)
+}
+
+// DoubleScalarMultCT sets c to ka*p + kb*q and returns c, in constant time with
+// respect to ka and kb. p and q must be in G1. DoubleScalarMultCT splits both
+// scalars as in ScalarMultCT and shares the doublings of the four halves.
+func (c *curvePoint) DoubleScalarMultCT(ka *[scalarWords]uint64, p *curvePoint, kb *[scalarWords]uint64, q *curvePoint) *curvePoint {
+	ka0, ka1 := glvDecompose(ka)
+	kb0, kb1 := glvDecompose(kb)
+	tp0, tp1 := curveGLVTablesCT(p)
+	tq0, tq1 := curveGLVTablesCT(q)
+
+	return c.fixedWindowCT(
+		[]*[ctWindowSize]curvePointProj{tp0, tp1, tq0, tq1},
+		[]*[scalarWords]uint64{&ka0, &ka1, &kb0, &kb1},
+		glvWindows,
+	)
+}
+
+// curveGLVTablesCT returns the multiples i*a and i*x²*a = -sigma(i*a) used by
+// the constant-time GLV method.
+func curveGLVTablesCT(a *curvePoint) (t0, t1 *[ctWindowSize]curvePointProj) {
+	t0, t1 = new([ctWindowSize]curvePointProj), new([ctWindowSize]curvePointProj)
+	t0[0].SetInfinity()
+	t0[1].FromJacobian(a)
+	for i := 2; i < ctWindowSize; i++ {
+		t0[i].Add(&t0[i-1], &t0[1])
+	}
+	for i := range t1 {
+		fqMul(&t1[i].x, &t0[i].x, fqBeta)
+		fqNeg(&t1[i].y, &t0[i].y)
+		t1[i].z = t0[i].z
+	}
+	return t0, t1
+}
+
+// fixedWindowCT sets c to the sum of ks[j]*tables[j][1] and returns c, in
+// constant time with respect to the scalars, where tables[j][i] is i times a
+// point and the scalars have the given number of windows. The scalars share a
+// single chain of doublings.
+func (c *curvePoint) fixedWindowCT(tables []*[ctWindowSize]curvePointProj, ks []*[scalarWords]uint64, windows int) *curvePoint {
+	p, t := new(curvePointProj).SetInfinity(), new(curvePointProj)
+	for i := windows - 1; i >= 0; i-- {
+		for j := 0; j < ctWindowBits; j++ {
+			p.Double(p)
+		}
+		for j, k := range ks {
+			t.Lookup(tables[j], ctWindow(k, i))
+			p.Add(p, t)
+		}
+	}
+
+	return p.ToJacobian(c)
+}
+
+// Neg sets c to -a and returns c.
+func (c *curvePoint) Neg(a *curvePoint) *curvePoint {
+	c.Set(a)
+	fqNeg(&c.y, &c.y)
+	return c
+}
+
 // ToAffine sets a to its affine value and returns a.
 func (a *curvePoint) ToAffine() *curvePoint {
 	if a.z == *new(fq).SetUint64(1) {
 		return a
 	}
 
-	// TODO create new curve point
 	if a.IsInfinity() {
-		//  If this bit is set, the remaining bits of the group element's encoding should be set to zero.
-		//pointAtInfinityMask
-		return nil
+		return a
 	}
 
 	zInv, zInvSqr, zInvCube := new(fq), new(fq), new(fq)
 	fqInv(zInv, &a.z)
-	fqMul(zInvSqr, zInv, zInv)
+	fqSqr(zInvSqr, zInv)
 	fqMul(zInvCube, zInvSqr, zInv)
 	fqMul(&a.x, &a.x, zInvSqr)
 	fqMul(&a.y, &a.y, zInvCube)
@@ -289,149 +602,763 @@ func (a *curvePoint) ToAffine() *curvePoint {
 	return a
 }
 
-// CompressedEncode converts a curve point into the uncompressed form specified in
-// See https://github.com/zkcrypto/pairing/tree/master/src/bls12_381#serialization.
+// curvePointProj is an elliptic curve point in homogeneous projective
+// coordinates, (X : Y : Z) with x = X/Z and y = Y/Z. Its addition formulas are
+// complete, so they do not branch on the input points.
+// See https://eprint.iacr.org/2015/1060.pdf.
+type curvePointProj struct {
+	x, y, z fq
+}
+
+// SetInfinity sets c to the point at infinity, (0 : 1 : 0), and returns c.
+func (c *curvePointProj) SetInfinity() *curvePointProj {
+	c.x, c.z = fq{}, fq{}
+	c.y.SetUint64(1)
+	return c
+}
+
+// FromJacobian sets c to the value of a and returns c.
+func (c *curvePointProj) FromJacobian(a *curvePoint) *curvePointProj {
+	// (X : Y : Z) = (X Z : Y : Z³)
+	isInfinity := fqIsZero(&a.z)
+	fqMul(&c.x, &a.x, &a.z)
+	fqSqr(&c.z, &a.z)
+	fqMul(&c.z, &c.z, &a.z)
+	fqCMov(&c.y, &a.y, new(fq).SetUint64(1), isInfinity)
+	return c
+}
+
+// ToJacobian sets a to the value of c and returns a.
+func (c *curvePointProj) ToJacobian(a *curvePoint) *curvePoint {
+	// (X : Y : Z) = (X Z : Y Z² : Z)
+	t := new(fq)
+	fqSqr(t, &c.z)
+	fqMul(&a.y, &c.y, t)
+	fqMul(&a.x, &c.x, &c.z)
+	a.z = c.z
+	return a
+}
+
+// Lookup sets c to table[i] and returns c, in constant time with respect to i.
+func (c *curvePointProj) Lookup(table *[ctWindowSize]curvePointProj, i uint64) *curvePointProj {
+	*c = curvePointProj{}
+	for j := range table {
+		b := ctEqual(uint64(j), i)
+		fqCMov(&c.x, &c.x, &table[j].x, b)
+		fqCMov(&c.y, &c.y, &table[j].y, b)
+		fqCMov(&c.z, &c.z, &table[j].z, b)
+	}
+	return c
+}
+
+// Add sets c to the sum a+b and returns c.
+// See https://eprint.iacr.org/2015/1060.pdf - Algorithm 7.
+func (c *curvePointProj) Add(a, b *curvePointProj) *curvePointProj {
+	t0, t1, t2, t3, t4 := new(fq), new(fq), new(fq), new(fq), new(fq)
+	x3, y3, z3 := new(fq), new(fq), new(fq)
+	fqMul(t0, &a.x, &b.x)
+	fqMul(t1, &a.y, &b.y)
+	fqMul(t2, &a.z, &b.z)
+	fqAdd(t3, &a.x, &a.y)
+	fqAdd(t4, &b.x, &b.y)
+	fqMul(t3, t3, t4)
+	fqAdd(t4, t0, t1)
+	fqSub(t3, t3, t4)
+	fqAdd(t4, &a.y, &a.z)
+	fqAdd(x3, &b.y, &b.z)
+	fqMul(t4, t4, x3)
+	fqAdd(x3, t1, t2)
+	fqSub(t4, t4, x3)
+	fqAdd(x3, &a.x, &a.z)
+	fqAdd(y3, &b.x, &b.z)
+	fqMul(x3, x3, y3)
+	fqAdd(y3, t0, t2)
+	fqSub(y3, x3, y3)
+	fqAdd(x3, t0, t0)
+	fqAdd(t0, x3, t0)
+	fqMul(t2, fqCurveB3, t2)
+	fqAdd(z3, t1, t2)
+	fqSub(t1, t1, t2)
+	fqMul(y3, fqCurveB3, y3)
+	fqMul(x3, t4, y3)
+	fqMul(t2, t3, t1)
+	fqSub(x3, t2, x3)
+	fqMul(y3, y3, t0)
+	fqMul(t1, t1, z3)
+	fqAdd(y3, t1, y3)
+	fqMul(t0, t0, t3)
+	fqMul(z3, z3, t4)
+	fqAdd(z3, z3, t0)
+	c.x, c.y, c.z = *x3, *y3, *z3
+	return c
+}
+
+// Double sets c to 2*a and returns c.
+// See https://eprint.iacr.org/2015/1060.pdf - Algorithm 9.
+func (c *curvePointProj) Double(a *curvePointProj) *curvePointProj {
+	t0, t1, t2 := new(fq), new(fq), new(fq)
+	x3, y3, z3 := new(fq), new(fq), new(fq)
+	fqSqr(t0, &a.y)
+	fqAdd(z3, t0, t0)
+	fqAdd(z3, z3, z3)
+	fqAdd(z3, z3, z3)
+	fqMul(t1, &a.y, &a.z)
+	fqSqr(t2, &a.z)
+	fqMul(t2, fqCurveB3, t2)
+	fqMul(x3, t2, z3)
+	fqAdd(y3, t0, t2)
+	fqMul(z3, t1, z3)
+	fqAdd(t1, t2, t2)
+	fqAdd(t2, t1, t2)
+	fqSub(t0, t0, t2)
+	fqMul(y3, t0, y3)
+	fqAdd(y3, x3, y3)
+	fqMul(t1, &a.x, &a.y)
+	fqMul(x3, t0, t1)
+	fqAdd(x3, x3, x3)
+	c.x, c.y, c.z = *x3, *y3, *z3
+	return c
+}
+
+// AddMixed sets c to the sum a+b and returns c.
+// See https://eprint.iacr.org/2015/1060.pdf - Algorithm 8.
+func (c *curvePointProj) AddMixed(a *curvePointProj, b *curvePointAffine) *curvePointProj {
+	t0, t1, t2, t3, t4 := new(fq), new(fq), new(fq), new(fq), new(fq)
+	x3, y3, z3 := new(fq), new(fq), new(fq)
+	fqMul(t0, &a.x, &b.x)
+	fqMul(t1, &a.y, &b.y)
+	fqAdd(t3, &b.x, &b.y)
+	fqAdd(t4, &a.x, &a.y)
+	fqMul(t3, t3, t4)
+	fqAdd(t4, t0, t1)
+	fqSub(t3, t3, t4)
+	fqMul(t4, &b.y, &a.z)
+	fqAdd(t4, t4, &a.y)
+	fqMul(y3, &b.x, &a.z)
+	fqAdd(y3, y3, &a.x)
+	fqAdd(x3, t0, t0)
+	fqAdd(t0, x3, t0)
+	fqMul(t2, fqCurveB3, &a.z)
+	fqAdd(z3, t1, t2)
+	fqSub(t1, t1, t2)
+	fqMul(y3, fqCurveB3, y3)
+	fqMul(x3, t4, y3)
+	fqMul(t2, t3, t1)
+	fqSub(x3, t2, x3)
+	fqMul(y3, y3, t0)
+	fqMul(t1, t1, z3)
+	fqAdd(y3, t1, y3)
+	fqMul(t0, t0, t3)
+	fqMul(z3, z3, t4)
+	fqAdd(z3, z3, t0)
+	c.x, c.y, c.z = *x3, *y3, *z3
+	return c
+}
+
+// CMov sets c to b if v is 1 and to a if v is 0 and returns c, in constant
+// time.
+func (c *curvePointProj) CMov(a, b *curvePointProj, v uint64) *curvePointProj {
+	fqCMov(&c.x, &a.x, &b.x, v)
+	fqCMov(&c.y, &a.y, &b.y, v)
+	fqCMov(&c.z, &a.z, &b.z, v)
+	return c
+}
+
+// curvePointAffine is an elliptic curve point in affine coordinates, (x, y). It
+// cannot represent the point at infinity.
+type curvePointAffine struct {
+	x, y fq
+}
+
+// Lookup sets c to row[i-1] and returns c, in constant time with respect to i.
+// c is set to (0, 0) if i is 0.
+func (c *curvePointAffine) Lookup(row *[ctWindowSize - 1]curvePointAffine, i uint64) *curvePointAffine {
+	*c = curvePointAffine{}
+	for j := range row {
+		b := ctEqual(uint64(j+1), i)
+		fqCMov(&c.x, &c.x, &row[j].x, b)
+		fqCMov(&c.y, &c.y, &row[j].y, b)
+	}
+	return c
+}
+
+// curveFixedBase holds the multiples j*16^i*a of a fixed point a, for all the
+// windows i of a scalar and all the non-zero window values j, in affine
+// coordinates. The product of a scalar and a is then the sum of one entry per
+// window, without doublings.
+type curveFixedBase struct {
+	table      [ctWindows][ctWindowSize - 1]curvePointAffine
+	isInfinity bool
+}
+
+// Init sets t to the table of a and returns t. a must be in G1.
+func (t *curveFixedBase) Init(a *curvePoint) *curveFixedBase {
+	t.isInfinity = a.IsInfinity()
+	if t.isInfinity {
+		return t
+	}
+
+	// Since r is prime and j*16^i is never a multiple of r, none of the
+	// multiples is the point at infinity.
+	var proj [ctWindows][ctWindowSize - 1]curvePointProj
+	base := new(curvePointProj).FromJacobian(a)
+	for i := range proj {
+		proj[i][0] = *base
+		for j := 1; j < ctWindowSize-1; j++ {
+			proj[i][j].Add(&proj[i][j-1], base)
+		}
+		base.Add(&proj[i][ctWindowSize-2], base)
+	}
+
+	// Montgomery's trick: a single inversion for all the z coordinates.
+	var acc [ctWindows * (ctWindowSize - 1)]fq
+	prod := *new(fq).SetUint64(1)
+	for i := range proj {
+		for j := range proj[i] {
+			acc[i*(ctWindowSize-1)+j] = prod
+			fqMul(&prod, &prod, &proj[i][j].z)
+		}
+	}
+	inv := new(fq)
+	fqInv(inv, &prod)
+	for i := len(proj) - 1; i >= 0; i-- {
+		for j := len(proj[i]) - 1; j >= 0; j-- {
+			zInv := new(fq)
+			fqMul(zInv, inv, &acc[i*(ctWindowSize-1)+j])
+			fqMul(inv, inv, &proj[i][j].z)
+			fqMul(&t.table[i][j].x, &proj[i][j].x, zInv)
+			fqMul(&t.table[i][j].y, &proj[i][j].y, zInv)
+		}
+	}
+
+	return t
+}
+
+// ScalarMultFixedBase sets c to k*a, where a is the point of the table t, and
+// returns c, in constant time with respect to k.
+func (c *curvePoint) ScalarMultFixedBase(t *curveFixedBase, k *[scalarWords]uint64) *curvePoint {
+	if t.isInfinity {
+		return c.Set(&curvePoint{})
+	}
+
+	p, sum, e := new(curvePointProj).SetInfinity(), new(curvePointProj), new(curvePointAffine)
+	for i := range t.table {
+		w := ctWindow(k, i)
+		e.Lookup(&t.table[i], w)
+		sum.AddMixed(p, e)
+		p.CMov(p, sum, 1^ctEqual(w, 0))
+	}
+
+	return p.ToJacobian(c)
+}
+
+// MultiExp sets c to the sum of scalars[i]*points[i] and returns c. The points
+// must be in G1 and have the same length as the scalars. MultiExp uses the
+// bucket method with signed digits and affine bucket additions that share their
+// field inversions, and spreads the windows across workers goroutines.
+// See https://eprint.iacr.org/2012/549.pdf - Section 4.
+func (c *curvePoint) MultiExp(points []*curvePoint, scalars [][scalarWords]uint64, workers int) *curvePoint {
+	// The points at infinity do not contribute to the sum.
+	finite := make([]*curvePoint, 0, len(points))
+	ks := make([][scalarWords]uint64, 0, len(scalars))
+	for i, point := range points {
+		if !point.IsInfinity() {
+			finite = append(finite, point)
+			ks = append(ks, scalars[i])
+		}
+	}
+	if len(finite) == 0 {
+		return c.Set(&curvePoint{})
+	}
+
+	affine := curveBatchToAffine(finite)
+	window := multiExpWindow(len(finite))
+	digits := multiExpDigits(ks, window)
+	sums := make([]curvePoint, len(digits))
+	parallel(len(digits), workers, func(w int) {
+		sums[w] = *curveBucketSum(affine, digits[w], window)
+	})
+
+	p := new(curvePoint)
+	for w := len(sums) - 1; w >= 0; w-- {
+		for i := uint(0); i < window; i++ {
+			p.Double(p)
+		}
+		p.Add(p, &sums[w])
+	}
+
+	return c.Set(p)
+}
+
+// curveBatchToAffine returns the affine coordinates of the points, with a
+// single field inversion. None of the points can be the point at infinity.
+func curveBatchToAffine(points []*curvePoint) []curvePointAffine {
+	// acc[i] is the product of the z coordinates of points[:i].
+	acc := make([]fq, len(points))
+	prod := *new(fq).SetUint64(1)
+	for i, point := range points {
+		acc[i] = prod
+		fqMul(&prod, &prod, &point.z)
+	}
+	inv := new(fq)
+	fqInv(inv, &prod)
+
+	ret := make([]curvePointAffine, len(points))
+	zInv, zInvSqr := new(fq), new(fq)
+	for i := len(points) - 1; i >= 0; i-- {
+		fqMul(zInv, inv, &acc[i])
+		fqMul(inv, inv, &points[i].z)
+		fqSqr(zInvSqr, zInv)
+		fqMul(&ret[i].x, &points[i].x, zInvSqr)
+		fqMul(zInvSqr, zInvSqr, zInv)
+		fqMul(&ret[i].y, &points[i].y, zInvSqr)
+	}
+
+	return ret
+}
+
+// curveBucketSum returns the sum of digits[i]*points[i] for the digits of a
+// window of the given size.
+func curveBucketSum(points []curvePointAffine, digits []int32, window uint) *curvePoint {
+	b := newCurveBuckets(1<<(window-1), len(points) >= multiExpBatchMinPoints)
+	e := new(curvePointAffine)
+	for i, d := range digits {
+		switch {
+		case d > 0:
+			b.Add(int(d-1), &points[i])
+		case d < 0:
+			e.x = points[i].x
+			fqNeg(&e.y, &points[i].y)
+			b.Add(int(-d-1), e)
+		}
+	}
+	b.Flush()
+
+	// sum = sum((i+1)*buckets[i]) = sum(sum(buckets[j], j >= i)).
+	running, sum := new(curvePoint), new(curvePoint)
+	for i := len(b.buckets) - 1; i >= 0; i-- {
+		if b.isSet[i] {
+			running.AddMixed(running, &b.buckets[i])
+		}
+		running.Add(running, &b.overflow[i])
+		sum.Add(sum, running)
+	}
+
+	return sum
+}
+
+// curveBuckets accumulates points in buckets. If batching is enabled, the
+// buckets are affine and the additions are delayed and processed in batches
+// that share a single field inversion. Otherwise, or if a bucket is already
+// part of the current batch, the points are added to a jacobian overflow
+// bucket instead.
+type curveBuckets struct {
+	buckets  []curvePointAffine
+	isSet    []bool
+	inBatch  []bool
+	overflow []curvePoint
+
+	batching bool
+	batch    []int
+	points   []curvePointAffine
+}
+
+// newCurveBuckets returns n empty buckets.
+func newCurveBuckets(n int, batching bool) *curveBuckets {
+	return &curveBuckets{
+		buckets:  make([]curvePointAffine, n),
+		isSet:    make([]bool, n),
+		inBatch:  make([]bool, n),
+		overflow: make([]curvePoint, n),
+		batching: batching,
+		batch:    make([]int, 0, multiExpBatchSize),
+		points:   make([]curvePointAffine, 0, multiExpBatchSize),
+	}
+}
+
+// Add adds a to the bucket i.
+func (b *curveBuckets) Add(i int, a *curvePointAffine) {
+	switch {
+	case !b.batching || b.inBatch[i]:
+		b.overflow[i].AddMixed(&b.overflow[i], a)
+	case !b.isSet[i]:
+		b.buckets[i], b.isSet[i] = *a, true
+	case b.buckets[i].x == a.x:
+		// a is either the bucket or its opposite.
+		if b.buckets[i].y == a.y {
+			b.double(i)
+		} else {
+			b.isSet[i] = false
+		}
+	default:
+		b.inBatch[i] = true
+		b.batch = append(b.batch, i)
+		b.points = append(b.points, *a)
+		if len(b.batch) == multiExpBatchSize {
+			b.flushBatch()
+		}
+	}
+}
+
+// Flush processes all the delayed additions.
+func (b *curveBuckets) Flush() {
+	if len(b.batch) > 0 {
+		b.flushBatch()
+	}
+}
+
+// flushBatch adds the points of the batch to their buckets.
+// See https://hyperelliptic.org/EFD/g1p/auto-shortw.html - Addition.
+func (b *curveBuckets) flushBatch() {
+	// inverses of x2 - x1, with Montgomery's trick.
+	dx := make([]fq, len(b.batch))
+	acc := make([]fq, len(b.batch))
+	prod := *new(fq).SetUint64(1)
+	for j, i := range b.batch {
+		fqSub(&dx[j], &b.points[j].x, &b.buckets[i].x)
+		acc[j] = prod
+		fqMul(&prod, &prod, &dx[j])
+	}
+	inv := new(fq)
+	fqInv(inv, &prod)
+
+	lambda, x3, t := new(fq), new(fq), new(fq)
+	for j := len(b.batch) - 1; j >= 0; j-- {
+		i := b.batch[j]
+		p, q := &b.buckets[i], &b.points[j]
+
+		// lambda = (y2 - y1) / (x2 - x1)
+		fqMul(t, inv, &acc[j])
+		fqMul(inv, inv, &dx[j])
+		fqSub(lambda, &q.y, &p.y)
+		fqMul(lambda, lambda, t)
+
+		// x3 = lambda² - x1 - x2, y3 = lambda (x1 - x3) - y1
+		fqSqr(x3, lambda)
+		fqSub(x3, x3, &p.x)
+		fqSub(x3, x3, &q.x)
+		fqSub(t, &p.x, x3)
+		fqMul(t, t, lambda)
+		fqSub(&p.y, t, &p.y)
+		p.x = *x3
+
+		b.inBatch[i] = false
+	}
+
+	b.batch, b.points = b.batch[:0], b.points[:0]
+}
+
+// double doubles the bucket i.
+// See https://hyperelliptic.org/EFD/g1p/auto-shortw.html - Doubling.
+func (b *curveBuckets) double(i int) {
+	p := &b.buckets[i]
+
+	// lambda = 3 x² / 2 y, which is defined since G1 has no point of order 2.
+	lambda, t, x3 := new(fq), new(fq), new(fq)
+	fqAdd(t, &p.y, &p.y)
+	fqInv(t, t)
+	fqSqr(lambda, &p.x)
+	fqAdd(x3, lambda, lambda)
+	fqAdd(lambda, lambda, x3)
+	fqMul(lambda, lambda, t)
+
+	// x3 = lambda² - 2 x, y3 = lambda (x - x3) - y
+	fqSqr(x3, lambda)
+	fqSub(x3, x3, &p.x)
+	fqSub(x3, x3, &p.x)
+	fqSub(t, &p.x, x3)
+	fqMul(t, t, lambda)
+	fqSub(&p.y, t, &p.y)
+	p.x = *x3
+}
+
+// Marshal converts a curve point into the uncompressed form, x || y, specified
+// in https://github.com/zkcrypto/pairing/tree/master/src/bls12_381#serialization.
 func (a *curvePoint) Marshal() []byte {
-	a.ToAffine()
+	ret := make([]byte, 2*fqByteLen)
+	if a.IsInfinity() {
+		ret[0] |= pointAtInfinityMask
+		return ret
+	}
 
-	x := new(fq).MontgomeryDecode(&a.x)
-	y := new(fq).MontgomeryDecode(&a.y)
+	p := new(curvePoint).Set(a).ToAffine()
+	copy(ret, new(fq).MontgomeryDecode(&p.x).Bytes())
+	copy(ret[fqByteLen:], new(fq).MontgomeryDecode(&p.y).Bytes())
 
-	ret := make([]byte, fqByteLen*2)
-	copy(ret, x.Bytes())
-	copy(ret[fqByteLen:], y.Bytes())
+	return ret
+}
+
+// MarshalCompressed converts a curve point into the compressed form, x and the
+// sign of y, specified in
+// https://github.com/zkcrypto/pairing/tree/master/src/bls12_381#serialization.
+func (a *curvePoint) MarshalCompressed() []byte {
+	ret := make([]byte, fqByteLen)
+	if a.IsInfinity() {
+		ret[0] |= compressedFormMask | pointAtInfinityMask
+		return ret
+	}
 
-	// TODO review
-	//if cp.IsInfinity() {
-	//	ret[0] |= pointAtInfinityMask
-	//}
+	p := new(curvePoint).Set(a).ToAffine()
+	copy(ret, new(fq).MontgomeryDecode(&p.x).Bytes())
+	ret[0] |= compressedFormMask
+	if fqIsLexLargest(&p.y) {
+		ret[0] |= largestYMask
+	}
 
 	return ret
 }
 
-// Unmarshal decodes a curve point, serialized by Marshal.
-// It is an error if the point is not on the curve.
+// Unmarshal sets cp to the result of converting the output of Marshal back
+// into a curve point. On error, cp is left unchanged.
 func (cp *curvePoint) Unmarshal(data []byte) error {
 	if len(data) != 2*fqByteLen {
-		// TODO error
+		return ErrInvalidLength
+	}
+	if data[0]&(compressedFormMask|largestYMask) != 0 {
+		return ErrInvalidFlags
+	}
+	if data[0]&pointAtInfinityMask != 0 {
+		if !isZeroEncoding(data) {
+			return ErrInvalidInfinity
+		}
+		*cp = curvePoint{}
 		return nil
 	}
 
-	if data[0]&compressedFormMask != 0 { // uncompressed form
-		// TODO error
-		return nil
+	x, err := new(fq).SetBytes(data[:fqByteLen])
+	if err != nil {
+		return ErrNonCanonical
+	}
+	y, err := new(fq).SetBytes(data[fqByteLen:])
+	if err != nil {
+		return ErrNonCanonical
 	}
+	p := curvePoint{x: *x, y: *y, z: *new(fq).SetUint64(1)}
+	if !p.IsOnCurve() {
+		return ErrNotOnCurve
+	}
+	*cp = p
 
-	if data[0]&pointAtInfinityMask == 1 {
+	return nil
+}
 
-	} else {
-		cp.z = *new(fq).SetUint64(1)
+// UnmarshalCompressed sets cp to the result of converting the output of
+// MarshalCompressed back into a curve point. On error, cp is left unchanged.
+func (cp *curvePoint) UnmarshalCompressed(data []byte) error {
+	if len(data) != fqByteLen {
+		return ErrInvalidLength
+	}
+	if data[0]&compressedFormMask == 0 {
+		return ErrInvalidFlags
+	}
+	if data[0]&pointAtInfinityMask != 0 {
+		if !isZeroEncoding(data) {
+			return ErrInvalidInfinity
+		}
+		*cp = curvePoint{}
+		return nil
 	}
 
-	var err error
-	fqX, fqY := new(fq), new(fq)
-	_, err = fqX.SetInt(new(big.Int).SetBytes(data[:fqByteLen]))
+	buf := make([]byte, fqByteLen)
+	copy(buf, data)
+	buf[0] &^= flagsMask
+	x, err := new(fq).SetBytes(buf)
 	if err != nil {
-		return err
+		return ErrNonCanonical
 	}
-	cp.x = *fqX
-	_, err = fqY.SetInt(new(big.Int).SetBytes(data[fqByteLen:]))
-	if err != nil {
-		return err
+
+	// y² = x³ + b
+	y := new(fq)
+	fqSqr(y, x)
+	fqMul(y, y, x)
+	fqAdd(y, y, fqCurveB)
+	if !fqSqrt(y, y) {
+		return ErrNotOnCurve
+	}
+	if fqIsLexLargest(y) != (data[0]&largestYMask != 0) {
+		fqNeg(y, y)
 	}
-	cp.y = *fqY
+	cp.x, cp.y, cp.z = *x, *y, *new(fq).SetUint64(1)
 
 	return nil
 }
 
-// HashToPoint sets c to the curve point that results from the given slice of bytes
-// and returns c. The point is not guaranteed to be in a particular subgroup.
-// See https://eprint.iacr.org/2019/403.pdf - Section 5, Construction #2.
-func (c *curvePoint) HashToPoint(msg []byte) *curvePoint {
-	t0 := new(curvePoint).SWUMap(hp(msg))
-	t1 := new(curvePoint).SWUMap(hp(msg))
-	// add note about sum before converting to E(Fq)
-	t0.iso11(t0.Add(t0, t1))
+// isZeroEncoding reports whether all the bits of the encoding other than the
+// compression and infinity flags are zero.
+func isZeroEncoding(data []byte) bool {
+	acc := data[0] &^ (compressedFormMask | pointAtInfinityMask)
+	for _, b := range data[1:] {
+		acc |= b
+	}
+	return acc == 0
+}
 
-	return c
+// HashToPoint sets c to the curve point that results from the given slice of
+// bytes and domain separation tag and returns c. The point is not guaranteed
+// to be in a particular subgroup.
+// See https://www.rfc-editor.org/rfc/rfc9380.html#section-3.
+func (c *curvePoint) HashToPoint(msg, dst []byte) *curvePoint {
+	u := hashToFq(msg, dst, 2)
+	t0 := new(curvePoint).MapToCurve(&u[0])
+	t1 := new(curvePoint).MapToCurve(&u[1])
+
+	return c.Add(t0, t1)
 }
 
-// SWUMap maps a value of the finite field to a point in the elliptic curve.
-// The point is not guaranteed to be in a particular subgroup.
-// SWUMap implements an optimized version of the SWU map.
-// See https://eprint.iacr.org/2019/403.pdf - Section 4.4.
+// EncodeToPoint sets c to the curve point that results from the given slice of
+// bytes and domain separation tag and returns c. Unlike HashToPoint, only one
+// field element is mapped, so the output distribution is nonuniform. The point
+// is not guaranteed to be in a particular subgroup.
+// See https://www.rfc-editor.org/rfc/rfc9380.html#section-3.
+func (c *curvePoint) EncodeToPoint(msg, dst []byte) *curvePoint {
+	u := hashToFq(msg, dst, 1)
+	return c.MapToCurve(&u[0])
+}
+
+// ClearCofactor sets c to h_eff*a, which is in G1 for any a on the curve, and
+// returns c. Since x is negative, h_eff = 1 - x = 1 + u.
+// See https://www.rfc-editor.org/rfc/rfc9380.html#section-8.8.1.
+func (c *curvePoint) ClearCofactor(a *curvePoint) *curvePoint {
+	p := new(curvePoint).mulByU(a)
+	return c.Add(p, a)
+}
+
+// mulByU sets c to the product of a and the absolute value of the curve
+// parameter and returns c.
+func (c *curvePoint) mulByU(a *curvePoint) *curvePoint {
+	p := new(curvePoint).Set(a)
+	for i := uArrLen - 2; i >= 0; i-- {
+		p.Double(p)
+		if uArr[i] == 1 {
+			p.Add(p, a)
+		}
+	}
+
+	return c.Set(p)
+}
+
+// MapToCurve sets a to the point of E1 that results from mapping t to E1´
+// and applying the 11-isogeny and returns a. The point is not guaranteed to
+// be in a particular subgroup.
+func (a *curvePoint) MapToCurve(t *fq) *curvePoint {
+	return a.iso11(a.SWUMap(t))
+}
+
+// SWUMap maps a value of the finite field to a point in the 11-isogenous
+// curve E1´ and returns a. SWUMap runs in constant time.
+// See https://www.rfc-editor.org/rfc/rfc9380.html#appendix-F.2 and the
+// optimized version for q ≡ 3 (mod 4) in
+// https://www.rfc-editor.org/rfc/rfc9380.html#appendix-G.2.1.
 func (a *curvePoint) SWUMap(t *fq) *curvePoint {
-	n, t0, t1 := new(fq), new(fq), new(fq)
-	fqMul(t0, t, t)
-	fqMul(t1, t0, t0)
-	fqNeg(t0, t0)
-	fqAdd(t0, t0, t1)
-	fqAdd(n, t0, new(fq).SetUint64(1))
-	fqMul(n, n, fqCurveB)
-
-	d := new(fq).Set(t0) // a = -1 for performance
-	u := new(fq)
-	fqMul(u, n, n)
-	fqMul(u, u, n)
-	fqMul(t0, d, d)
-	fqMul(t1, n, t0)
-	fqNeg(t1, t1)
-	fqAdd(u, u, t1)
-	fqMul(t0, d, t0)
-	fqMul(t1, t0, fqCurveB)
-	fqAdd(u, u, t1)
-
-	v := new(fq).Set(t0)
-	alpha := new(fq)
-	fqMul(alpha, u, v)
-	t0.Set(alpha)
-	fqMul(t0, t0, v)
-	fqMul(t0, t0, v)
-	// t0 frob
-	fqMul(alpha, alpha, t0)
-
-	fqMul(t0, alpha, alpha)
-	fqMul(t0, t0, v)
-	fqSub(t0, t0, u)
-
-	if (*t0 == fq{}) {
-		fqMul(&a.x, n, d)
-		fqMul(&a.y, alpha, v)
-		a.z.Set(d)
-	} else {
-		// TODO cache Et^2, t^3
-		fqMul(&a.x, n, d)
-		fqMul(&a.y, alpha, v)
-		a.z.Set(d)
-	}
-
-	// TODO to affine?
+	one := new(fq).SetUint64(1)
+	tv1, tv2, tv3, tv4 := new(fq), new(fq), new(fq), new(fq)
+	fqSqr(tv1, t)
+	fqMul(tv3, fqSWUZ, tv1)
+	fqSqr(tv2, tv3)
+
+	// x1 = xn / xd; the exceptional case xd = 0 is mapped to x1 = B´/(Z A´).
+	xd, x1n := new(fq), new(fq)
+	fqAdd(xd, tv2, tv3)
+	fqAdd(x1n, xd, one)
+	fqMul(x1n, x1n, fqIsoB)
+	fqMul(xd, xd, fqIsoA)
+	fqNeg(xd, xd)
+	fqMul(tv4, fqSWUZ, fqIsoA)
+	fqCMov(xd, xd, tv4, fqIsZero(xd))
+
+	// g(x1) = gx1 / gxd
+	gxd, gx1 := new(fq), new(fq)
+	fqSqr(tv2, xd)
+	fqMul(gxd, tv2, xd)
+	fqMul(tv2, fqIsoA, tv2)
+	fqSqr(gx1, x1n)
+	fqAdd(gx1, gx1, tv2)
+	fqMul(gx1, gx1, x1n)
+	fqMul(tv2, fqIsoB, gxd)
+	fqAdd(gx1, gx1, tv2)
+
+	// y1 = sqrt(gx1 / gxd) if g(x1) is square.
+	y1 := new(fq)
+	fqSqr(tv4, gxd)
+	fqMul(tv2, gx1, gxd)
+	fqMul(tv4, tv4, tv2)
+	fqExp(y1, tv4, qMinusThreeOverFour)
+	fqMul(y1, y1, tv2)
+
+	// x2 = Z t² x1 and y2 = sqrt(-Z³) t³ y1 otherwise.
+	x2n, y2 := new(fq), new(fq)
+	fqMul(x2n, tv3, x1n)
+	fqMul(y2, y1, fqSWUC2)
+	fqMul(y2, y2, tv1)
+	fqMul(y2, y2, t)
+
+	xn, y := new(fq), new(fq)
+	fqSqr(tv2, y1)
+	fqMul(tv2, tv2, gxd)
+	isSquare := fqEqual(tv2, gx1)
+	fqCMov(xn, x2n, x1n, isSquare)
+	fqCMov(y, y2, y1, isSquare)
+
+	// sgn0(y) = sgn0(t)
+	fqNeg(tv1, y)
+	fqCMov(y, tv1, y, 1^(fqSgn0(t)^fqSgn0(y)))
+
+	// (x, y) = (xn/xd, y) in jacobian coordinates.
+	fqMul(&a.x, xn, xd)
+	fqSqr(tv2, xd)
+	fqMul(tv2, tv2, xd)
+	fqMul(&a.y, y, tv2)
+	a.z.Set(xd)
 
 	return a
 }
 
-// look at isogeny of phore project simpler mul main loop or wrong?
-// iso11 implements the 11-isogeny from E1´(Fp) to E1(Fp).
-// See https://eprint.iacr.org/2019/403.pdf - 4.3 Isogeny maps.
+// iso11 implements the 11-isogeny from E1´(Fp) to E1(Fp). The rational maps
+// are evaluated on the jacobian coordinates of b so that no inversion is
+// required.
+// See https://www.rfc-editor.org/rfc/rfc9380.html#appendix-E.2.
 func (a *curvePoint) iso11(b *curvePoint) *curvePoint {
+	// zz[i] = z^2i
+	zz := make([]fq, len(iso11YNum))
+	zz[0].SetUint64(1)
+	fqSqr(&zz[1], &b.z)
+	for i := 2; i < len(zz); i++ {
+		fqMul(&zz[i], &zz[i-1], &zz[1])
+	}
+
+	// sum[i] = Σ kij x^j z^2(deg-j)
 	term := new(fq)
 	mul := new(fq)
 	var sum [4]fq
 	for i, ki := range iso11K {
-		sum[i].Set(ki[0])
+		deg := len(ki) - 1
+		sum[i] = fq{}
 		mul.SetUint64(1)
-		for _, kij := range ki[1:] {
-			fqMul(mul, mul, &b.x)
+		for j, kij := range ki {
 			fqMul(term, kij, mul)
+			fqMul(term, term, &zz[deg-j])
 			fqAdd(&sum[i], &sum[i], term)
+			fqMul(mul, mul, &b.x)
 		}
 	}
 
-	fqInv(&sum[1], &sum[1])
-	fqMul(&a.x, &sum[0], &sum[1])
-	fqInv(&sum[3], &sum[3])
-	fqMul(term, &sum[2], &sum[3])
-	fqMul(&a.y, &b.y, term)
+	// x = xNum / (xDen z²) and y = y yNum / (z³ yDen).
+	xDen, yDen := new(fq), new(fq)
+	fqMul(xDen, &sum[1], &zz[1])
+	fqMul(yDen, &sum[3], &zz[1])
+	fqMul(yDen, yDen, &b.z)
+
+	// (X, Y, Z) = (xNum xDen yDen², y yNum xDen³ yDen², xDen yDen)
+	t0, t1 := new(fq), new(fq)
+	fqSqr(t0, yDen)
+	fqSqr(t1, xDen)
+	fqMul(t1, t1, xDen)
+	fqMul(&a.y, &b.y, &sum[2])
+	fqMul(&a.y, &a.y, t1)
+	fqMul(&a.y, &a.y, t0)
+	fqMul(&a.x, &sum[0], xDen)
+	fqMul(&a.x, &a.x, t0)
+	fqMul(&a.z, xDen, yDen)
 
 	return a
 }
@@ -443,7 +1370,7 @@ func (a *curvePoint) SWEncode(b *fq) *curvePoint {
 	w, inv := new(fq), new(fq)
 	fqMul(w, fqSqrtNegThree, b)
 	fqMul(w, w, b)
-	fqMul(inv, b, b)
+	fqSqr(inv, b)
 	fqAdd(inv, inv, fqCurveBPlusOne)
 	fqInv(inv, inv)
 	fqMul(w, w, inv)
@@ -457,12 +1384,12 @@ func (a *curvePoint) SWEncode(b *fq) *curvePoint {
 		case 1:
 			fqSub(x, &fq{0x43F5FFFFFFFCAAAE, 0x32B7FFF2ED47FFFD, 0x7E83A49A2E99D69, 0xECA8F3318332BB7A, 0xEF148D1EA0F4C069, 0x40AB3263EFF0206}, x)
 		case 2:
-			fqMul(x, w, w)
+			fqSqr(x, w)
 			fqInv(x, x)
 			fqAdd(x, x, new(fq).SetUint64(1))
 		}
 
-		fqMul(y, x, x)
+		fqSqr(y, x)
 		fqMul(y, y, x)
 		fqAdd(y, y, fqCurveB)
 		if fqSqrt(y, y) {
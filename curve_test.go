@@ -5,6 +5,16 @@ import (
 	"testing"
 )
 
+// newAffineCurvePoint returns the curve point (x, y), given in hexadecimal.
+func newAffineCurvePoint(x, y string) curvePoint {
+	bigX, _ := new(big.Int).SetString(x, 0)
+	bigY, _ := new(big.Int).SetString(y, 0)
+	p := curvePoint{z: *new(fq).SetUint64(1)}
+	p.x.SetInt(bigX)
+	p.y.SetInt(bigY)
+	return p
+}
+
 func TestCurvePointSet(t *testing.T) {
 	tests := map[string]struct {
 		input, want curvePoint
@@ -93,7 +103,28 @@ func TestCurvePointIsInfinity(t *testing.T) {
 }
 
 func TestCurvePointAdd(t *testing.T) {
-	// TODO
+	a := new(curvePoint).HashToPoint([]byte("abc"), g1Domain)
+	b := new(curvePoint).Double(a)
+	// a in different jacobian coordinates.
+	c := new(curvePoint).Add(b, new(curvePoint).Neg(a))
+	tests := map[string]struct {
+		a, b, want *curvePoint
+	}{
+		"infinity + a":   {a: &curvePoint{}, b: a, want: a},
+		"a + infinity":   {a: a, b: &curvePoint{}, want: a},
+		"a + a":          {a: a, b: a, want: b},
+		"a + equivalent": {a: a, b: c, want: b},
+		"a + (-a)":       {a: a, b: new(curvePoint).Neg(c), want: &curvePoint{}},
+		"a + 2a":         {a: a, b: b, want: new(curvePoint).ScalarMult(a, big.NewInt(3))},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := new(curvePoint).Add(tc.a, tc.b)
+			if !got.isEquivalent(tc.want) {
+				t.Fatalf("expected: %v, got: %v", tc.want, got)
+			}
+		})
+	}
 }
 
 func TestCurvePointDouble(t *testing.T) {
@@ -204,6 +235,113 @@ func TestCurvePointSetBytes(t *testing.T) {
 	// TODO
 }
 
+// See https://www.rfc-editor.org/rfc/rfc9380.html#appendix-J.9.1.
+func TestCurvePointMapToCurve(t *testing.T) {
+	tests := map[string]struct {
+		u    string
+		want curvePoint
+	}{
+		"empty message, u0": {
+			u: "0x0ba14bd907ad64a016293ee7c2d276b8eae71f25a4b941eece7b0d89f17f75cb3ae5438a614fb61d6835ad59f29c564f",
+			want: newAffineCurvePoint(
+				"0x11a3cce7e1d90975990066b2f2643b9540fa40d6137780df4e753a8054d07580db3b7f1f03396333d4a359d1fe3766fe",
+				"0x0eeaf6d794e479e270da10fdaf768db4c96b650a74518fc67b04b03927754bac66f3ac720404f339ecdcc028afa091b7",
+			),
+		},
+		"empty message, u1": {
+			u: "0x019b9bd7979f12657976de2884c7cce192b82c177c80e0ec604436a7f538d231552f0d96d9f7babe5fa3b19b3ff25ac9",
+			want: newAffineCurvePoint(
+				"0x160003aaf1632b13396dbad518effa00fff532f604de1a7fc2082ff4cb0afa2d63b2c32da1bef2bf6c5ca62dc6b72f9c",
+				"0x0d8bb2d14e20cf9f6036152ed386d79189415b6d015a20133acb4e019139b94e9c146aaad5817f866c95d609a361735e",
+			),
+		},
+		"abc, u0": {
+			u: "0x0d921c33f2bad966478a03ca35d05719bdf92d347557ea166e5bba579eea9b83e9afa5c088573c2281410369fbd32951",
+			want: newAffineCurvePoint(
+				"0x125435adce8e1cbd1c803e7123f45392dc6e326d292499c2c45c5865985fd74fe8f042ecdeeec5ecac80680d04317d80",
+				"0x0e8828948c989126595ee30e4f7c931cbd6f4570735624fd25aef2fa41d3f79cfb4b4ee7b7e55a8ce013af2a5ba20bf2",
+			),
+		},
+		"abc, u1": {
+			u: "0x003574a00b109ada2f26a37a91f9d1e740dffd8d69ec0c35e1e9f4652c7dba61123e9dd2e76c655d956e2b3462611139",
+			want: newAffineCurvePoint(
+				"0x11def93719829ecda3b46aa8c31fc3ac9c34b428982b898369608e4f042babee6c77ab9218aad5c87ba785481eff8ae4",
+				"0x0007c9cef122ccf2efd233d6eb9bfc680aa276652b0661f4f820a653cec1db7ff69899f8e52b8e92b025a12c822a6ce6",
+			),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			bigU, _ := new(big.Int).SetString(tc.u, 0)
+			u, _ := new(fq).SetInt(bigU)
+			got := new(curvePoint).MapToCurve(u).ToAffine()
+			if *got != tc.want {
+				t.Fatalf("expected: %v, got: %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCurvePointClearCofactor(t *testing.T) {
+	tests := map[string]struct {
+		msg string
+	}{
+		"empty message": {msg: ""},
+		"abc":           {msg: "abc"},
+		"q128":          {msg: msgQ128},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := new(curvePoint).EncodeToPoint([]byte(tc.msg), g1EncodeDomain)
+			want := new(curvePoint).ScalarMult(p, g1EffectiveCofactor).ToAffine()
+			got := new(curvePoint).ClearCofactor(p).ToAffine()
+			if *got != *want {
+				t.Fatalf("expected: %v, got: %v", want, got)
+			}
+		})
+	}
+}
+
+// ClearCofactor is checked end to end against hash_to_curve: P = h_eff(Q0 + Q1),
+// where Qi is the map to curve of the field element ui.
+// See https://www.rfc-editor.org/rfc/rfc9380.html#appendix-J.9.1.
+func TestCurvePointClearCofactorHashToCurve(t *testing.T) {
+	tests := map[string]struct {
+		u0, u1 string
+		want   curvePoint
+	}{
+		"empty message": {
+			u0: "0x0ba14bd907ad64a016293ee7c2d276b8eae71f25a4b941eece7b0d89f17f75cb3ae5438a614fb61d6835ad59f29c564f",
+			u1: "0x019b9bd7979f12657976de2884c7cce192b82c177c80e0ec604436a7f538d231552f0d96d9f7babe5fa3b19b3ff25ac9",
+			want: newAffineCurvePoint(
+				"0x052926add2207b76ca4fa57a8734416c8dc95e24501772c814278700eed6d1e4e8cf62d9c09db0fac349612b759e79a1",
+				"0x08ba738453bfed09cb546dbb0783dbb3a5f1f566ed67bb6be0e8c67e2e81a4cc68ee29813bb7994998f3eae0c9c6a265",
+			),
+		},
+		"abc": {
+			u0: "0x0d921c33f2bad966478a03ca35d05719bdf92d347557ea166e5bba579eea9b83e9afa5c088573c2281410369fbd32951",
+			u1: "0x003574a00b109ada2f26a37a91f9d1e740dffd8d69ec0c35e1e9f4652c7dba61123e9dd2e76c655d956e2b3462611139",
+			want: newAffineCurvePoint(
+				"0x03567bc5ef9c690c2ab2ecdf6a96ef1c139cc0b2f284dca0a9a7943388a49a3aee664ba5379a7655d3c68900be2f6903",
+				"0x0b9c15f3fe6e5cf4211f346271d7b01c8f3b28be689c8429c85b67af215533311f0b8dfaaa154fa6b88176c229f2885d",
+			),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			bigU0, _ := new(big.Int).SetString(tc.u0, 0)
+			bigU1, _ := new(big.Int).SetString(tc.u1, 0)
+			u0, _ := new(fq).SetInt(bigU0)
+			u1, _ := new(fq).SetInt(bigU1)
+			p := new(curvePoint).Add(new(curvePoint).MapToCurve(u0), new(curvePoint).MapToCurve(u1))
+			got := new(curvePoint).ClearCofactor(p).ToAffine()
+			if *got != tc.want {
+				t.Fatalf("expected: %v, got: %v", tc.want, got)
+			}
+		})
+	}
+}
+
 func TestCurvePointSWEncode(t *testing.T) {
 	// TODO
 }
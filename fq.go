@@ -94,6 +94,30 @@ func (z *fq) SetUint64(x uint64) *fq {
 	return z.MontgomeryEncode(&fq{x})
 }
 
+// SetBytes sets z to the Montgomery value of the big-endian byte slice b and
+// returns z. b must be fqByteLen bytes long and its value smaller than q,
+// otherwise SetBytes returns nil and errOutOfBounds, and z is left unchanged.
+func (z *fq) SetBytes(b []byte) (*fq, error) {
+	if len(b) != fqByteLen {
+		return nil, errOutOfBounds
+	}
+
+	var x fq
+	for i := range x {
+		x[i] = binary.BigEndian.Uint64(b[fqByteLen-(i+1)*8:])
+	}
+	for i := fqLen - 1; i >= 0; i-- {
+		if x[i] != q64[i] {
+			if x[i] > q64[i] {
+				return nil, errOutOfBounds
+			}
+			return z.MontgomeryEncode(&x), nil
+		}
+	}
+
+	return nil, errOutOfBounds
+}
+
 // Bytes returns the absolute value of fq as a big-endian byte slice.
 func (x *fq) Bytes() []byte {
 	ret := make([]byte, fqByteLen)
@@ -137,7 +161,7 @@ func fqExp(z *fq, x *fq, y []uint64) {
 			if (word & (1 << j)) != 0 {
 				fqMul(ret, ret, &b)
 			}
-			fqMul(&b, &b, &b)
+			fqSqr(&b, &b)
 		}
 	}
 
@@ -152,7 +176,7 @@ func fqExp(z *fq, x *fq, y []uint64) {
 func fqSqrt(z, x *fq) bool {
 	x0, x1 := new(fq), new(fq)
 	fqExp(x1, x, []uint64{0xee7fbfffffffeaaa, 0x7aaffffac54ffff, 0xd9cc34a83dac3d89, 0xd91dd2e13ce144af, 0x92c6e9ed90d2eb35, 0x680447a8e5ff9a6})
-	fqMul(x0, x1, x1)
+	fqSqr(x0, x1)
 	fqMul(x0, x0, x)
 	if (*x0 == fq{0x43F5FFFFFFFCAAAE, 0x32B7FFF2ED47FFFD, 0x7E83A49A2E99D69, 0xECA8F3318332BB7A, 0xEF148D1EA0F4C069, 0x40AB3263EFF0206}) {
 		return false
@@ -162,6 +186,57 @@ func fqSqrt(z, x *fq) bool {
 	return true
 }
 
+// fqIsSquare returns 1 if x is a square in Fq, that is if its Legendre symbol
+// is 0 or 1, and 0 otherwise, in constant time.
+// See https://www.rfc-editor.org/rfc/rfc9380.html#section-4.
+func fqIsSquare(x *fq) uint64 {
+	// x^((q-1)/2) is 0, 1 or -1.
+	l := new(fq)
+	fqExp(l, x, qMinusOneOverTwo)
+	return fqIsZero(l) | fqEqual(l, new(fq).SetUint64(1))
+}
+
+// fqIsLexLargest reports whether x is lexicographically larger than -x, that
+// is, whether its standard form is larger than (q-1)/2.
+func fqIsLexLargest(x *fq) bool {
+	d := new(fq).MontgomeryDecode(x)
+	for i := fqLen - 1; i >= 0; i-- {
+		if d[i] != qMinusOneOverTwo[i] {
+			return d[i] > qMinusOneOverTwo[i]
+		}
+	}
+
+	return false
+}
+
+// fqCMov sets z to y if b is 1 and to x if b is 0, in constant time.
+func fqCMov(z, x, y *fq, b uint64) {
+	mask := -b
+	for i := range z {
+		z[i] = x[i] ^ (mask & (x[i] ^ y[i]))
+	}
+}
+
+// fqEqual returns 1 if x is equal to y and 0 otherwise, in constant time.
+func fqEqual(x, y *fq) uint64 {
+	var acc uint64
+	for i := range x {
+		acc |= x[i] ^ y[i]
+	}
+	return 1 ^ ((acc | -acc) >> (wordSize - 1))
+}
+
+// fqIsZero returns 1 if x is equal to 0 and 0 otherwise, in constant time.
+func fqIsZero(x *fq) uint64 {
+	return fqEqual(x, &fq{})
+}
+
+// fqSgn0 returns the sign of x, its parity in the standard form.
+// See https://www.rfc-editor.org/rfc/rfc9380.html#section-4.1.
+func fqSgn0(x *fq) uint64 {
+	return new(fq).MontgomeryDecode(x)[0] & 1
+}
+
 // fqLarge is used during the multiplication.
 type fqLarge [fqLen * 2]uint64
 
@@ -80,6 +80,180 @@ func (z *fq12) Sqr(x *fq12) *fq12 {
 	return z.Set(ret)
 }
 
+// CyclotomicSqr sets z to the product x*x and returns z. x must be an element
+// of the cyclotomic subgroup, of order q⁴ - q² + 1, such as the result of the
+// easy part of the final exponentiation.
+// See https://eprint.iacr.org/2009/565.pdf - Section 3.2.
+func (z *fq12) CyclotomicSqr(x *fq12) *fq12 {
+	// x = (g0 + g1 w) + (g2 + g3 w) v + (g4 + g5 w) v², where w² = v, is
+	// squared as three elements of fq4 = fq2[w].
+	var t0, t1, t2, t3, t4, t5 fq2
+	fq4Sqr(&t0, &t1, &x.c0.c0, &x.c1.c1)
+	fq4Sqr(&t2, &t3, &x.c1.c0, &x.c0.c2)
+	fq4Sqr(&t4, &t5, &x.c0.c1, &x.c1.c2)
+
+	var ret fq12
+	cyclotomicSqrSub(&ret.c0.c0, &t0, &x.c0.c0)
+	cyclotomicSqrAdd(&ret.c1.c1, &t1, &x.c1.c1)
+	cyclotomicSqrSub(&ret.c0.c1, &t2, &x.c0.c1)
+	cyclotomicSqrAdd(&ret.c1.c2, &t3, &x.c1.c2)
+	cyclotomicSqrAdd(&ret.c1.c0, t5.MulXi(&t5), &x.c1.c0)
+	cyclotomicSqrSub(&ret.c0.c2, &t4, &x.c0.c2)
+
+	return z.Set(&ret)
+}
+
+// fq4Sqr sets c0 + c1 w to the square (a + b w)² = (a² + ξb²) + ((a + b)² - a² -
+// b²) w of an element of fq4 = fq2[w], where w² = ξ.
+func fq4Sqr(c0, c1, a, b *fq2) {
+	var t0, t1 fq2
+	t0.Sqr(a)
+	t1.Sqr(b)
+	c1.Add(a, b)
+	c1.Sqr(c1).Sub(c1, &t0).Sub(c1, &t1)
+	c0.MulXi(&t1).Add(c0, &t0)
+}
+
+// cyclotomicSqrSub sets z to 3t - 2x.
+func cyclotomicSqrSub(z, t, x *fq2) {
+	z.Sub(t, x)
+	z.Add(z, z).Add(z, t)
+}
+
+// cyclotomicSqrAdd sets z to 3t + 2x.
+func cyclotomicSqrAdd(z, t, x *fq2) {
+	z.Add(t, x)
+	z.Add(z, z).Add(z, t)
+}
+
+// CyclotomicSqrCompressed sets z to the product x*x in Karabina's compressed
+// form and returns z. x must be an element of the cyclotomic subgroup. Only the
+// coefficients c0.c1, c0.c2, c1.c0 and c1.c2 of x are used and set in z, the
+// other two are recovered by fq12Decompress.
+// See https://eprint.iacr.org/2010/542.pdf - Section 3.2.
+func (z *fq12) CyclotomicSqrCompressed(x *fq12) *fq12 {
+	t0, t1, t2, t3 := new(fq2), new(fq2), new(fq2), new(fq2)
+	ret := new(fq12)
+
+	// c1.c0 = 3ξ((c0.c1 + c1.c2)² - c0.c1² - c1.c2²) + 2 c1.c0
+	s0 := new(fq2).Sqr(&x.c0.c1)
+	s1 := new(fq2).Sqr(&x.c1.c2)
+	t0.Add(&x.c0.c1, &x.c1.c2).Sqr(t0).Sub(t0, t1.Add(s0, s1)).MulXi(t0)
+	t1.Add(t0, &x.c1.c0)
+	ret.c1.c0.Add(t1, t1).Add(&ret.c1.c0, t0)
+
+	// c0.c2 = 3(ξ c1.c2² + c0.c1²) - 2 c0.c2
+	t0.MulXi(s1).Add(t0, s0)
+	t1.Sub(t0, &x.c0.c2)
+	ret.c0.c2.Add(t1, t1).Add(&ret.c0.c2, t0)
+
+	// c0.c1 = 3(c1.c0² + ξ c0.c2²) - 2 c0.c1
+	s2 := new(fq2).Sqr(&x.c1.c0)
+	s3 := new(fq2).Sqr(&x.c0.c2)
+	t0.MulXi(s3).Add(t0, s2)
+	t1.Sub(t0, &x.c0.c1)
+	ret.c0.c1.Add(t1, t1).Add(&ret.c0.c1, t0)
+
+	// c1.c2 = 3((c1.c0 + c0.c2)² - c1.c0² - c0.c2²) + 2 c1.c2
+	t0.Add(&x.c1.c0, &x.c0.c2).Sqr(t0).Sub(t0, t2.Add(s2, s3))
+	t3.Add(t0, &x.c1.c2)
+	ret.c1.c2.Add(t3, t3).Add(&ret.c1.c2, t0)
+
+	return z.Set(ret)
+}
+
+// fq12Decompress recovers the coefficients c0.c0 and c1.c1 of the elements of
+// the cyclotomic subgroup xs, given in Karabina's compressed form. The
+// inversions of the decompressions are batched into a single one.
+// See https://eprint.iacr.org/2010/542.pdf - Section 3.2.
+func fq12Decompress(xs []*fq12) {
+	// c1.c1 = n/d, where n/d = (ξ c1.c2² + 3 c0.c1² - 2 c0.c2)/(4 c1.c0) if
+	// c1.c0 is not 0 and n/d = 2 c0.c1 c1.c2/c0.c2 otherwise.
+	//
+	// If both c1.c0 and c0.c2 are 0, x is 1. The first relation,
+	// 4 c1.c1 c1.c0 = ξ c1.c2² + 3 c0.c1² - 2 c0.c2, holds for every element
+	// of the cyclotomic subgroup and becomes ξ c1.c2² = -3 c0.c1². -3 is a
+	// square in Fq, as q = 1 mod 3, and ξ is not a square in Fq², so c0.c1
+	// and c1.c2 are 0 as well and x = c0.c0 + c1.c1 w³ is in Fq⁴ = Fq²(w³).
+	// The only element of Fq⁴ in the cyclotomic subgroup is 1, since
+	// gcd(q⁴ - 1, q⁴ - q² + 1) divides gcd(q² - 2, 3) = 1.
+	ns, ds := make([]fq2, len(xs)), make([]fq2, len(xs))
+	one := new(fq2).SetOne()
+	t := new(fq2)
+	for i, x := range xs {
+		n, d := &ns[i], &ds[i]
+		switch {
+		case fq2IsZero(&x.c1.c0) == 0:
+			t.Sqr(&x.c0.c1)
+			n.Sub(t, &x.c0.c2).Add(n, n).Add(n, t)
+			t.Sqr(&x.c1.c2).MulXi(t)
+			n.Add(n, t)
+			d.Add(&x.c1.c0, &x.c1.c0).Add(d, d)
+		case fq2IsZero(&x.c0.c2) == 0:
+			n.Mul(&x.c0.c1, &x.c1.c2).Add(n, n)
+			d.Set(&x.c0.c2)
+		default:
+			d.Set(one)
+		}
+	}
+
+	// Montgomery's trick: a single inversion for all the denominators.
+	prods := make([]fq2, len(xs)+1)
+	prods[0].SetOne()
+	for i := range ds {
+		prods[i+1].Mul(&prods[i], &ds[i])
+	}
+	inv := new(fq2).Inv(&prods[len(xs)])
+	for i := len(xs) - 1; i >= 0; i-- {
+		x := xs[i]
+		if fq2IsZero(&x.c1.c0)&fq2IsZero(&x.c0.c2) == 1 {
+			x.SetOne()
+			inv.Mul(inv, &ds[i])
+			continue
+		}
+
+		x.c1.c1.Mul(&prods[i], inv).Mul(&x.c1.c1, &ns[i])
+		inv.Mul(inv, &ds[i])
+
+		// c0.c0 = ξ(2 c1.c1² + c1.c0 c1.c2 - 3 c0.c1 c0.c2) + 1
+		t0, t1 := new(fq2).Mul(&x.c0.c1, &x.c0.c2), new(fq2).Sqr(&x.c1.c1)
+		t1.Sub(t1, t0).Add(t1, t1).Sub(t1, t0)
+		t0.Mul(&x.c1.c0, &x.c1.c2)
+		x.c0.c0.Add(t1, t0).MulXi(&x.c0.c0).Add(&x.c0.c0, one)
+	}
+}
+
+// CyclotomicExp sets z=x**y, where y is given as little-endian 64 bit words,
+// and returns z. x must be an element of the cyclotomic subgroup.
+// CyclotomicExp runs in variable time: the powers x^(2^i) are computed with
+// compressed squarings and only those for the non-zero bits of y are
+// decompressed, all at once.
+func (z *fq12) CyclotomicExp(x *fq12, y []uint64) *fq12 {
+	n := len(y) * wordSize
+	for n > 0 && y[(n-1)/wordSize]>>uint((n-1)%wordSize)&1 == 0 {
+		n--
+	}
+
+	ret := new(fq12).SetOne()
+	if n > 0 && y[0]&1 == 1 {
+		ret.Set(x)
+	}
+	powers := make([]*fq12, 0, n)
+	sqr := new(fq12).Set(x)
+	for i := 1; i < n; i++ {
+		sqr.CyclotomicSqrCompressed(sqr)
+		if y[i/wordSize]>>uint(i%wordSize)&1 == 1 {
+			powers = append(powers, new(fq12).Set(sqr))
+		}
+	}
+	fq12Decompress(powers)
+	for _, p := range powers {
+		ret.Mul(ret, p)
+	}
+
+	return z.Set(ret)
+}
+
 // Inv sets z to 1/x and returns z.
 // See "Implementing cryptographic pairings", M. Scott - section 3.2.
 func (z *fq12) Inv(x *fq12) *fq12 {
@@ -118,3 +292,9 @@ func (z *fq12) Frobenius(x *fq12, power uint64) *fq12 {
 
 	return z.Set(ret)
 }
+
+// fq12CMov sets z to y if b is 1 and to x if b is 0, in constant time.
+func fq12CMov(z, x, y *fq12, b uint64) {
+	fq6CMov(&z.c0, &x.c0, &y.c0, b)
+	fq6CMov(&z.c1, &x.c1, &y.c1, b)
+}
@@ -390,6 +390,77 @@ func TestFq12Sqr(t *testing.T) {
 	}
 }
 
+func TestFq12CyclotomicSqr(t *testing.T) {
+	tests := map[string]struct {
+		input fq12
+	}{
+		"one":     {input: *new(fq12).SetOne()},
+		"pairing": {input: Pair(g1Gen, g2Gen).f},
+		"pairing of hashed points": {
+			input: Pair(new(G1Point).HashToPoint([]byte("abc")), new(G2Point).HashToPoint([]byte("def"))).f,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			want := new(fq12).Sqr(&tc.input)
+			got := new(fq12).CyclotomicSqr(&tc.input)
+			if *got != *want {
+				t.Fatalf("expected: %v, got: %v", want, got)
+			}
+		})
+	}
+}
+
+func TestFq12CyclotomicSqrCompressed(t *testing.T) {
+	inputs := map[string]fq12{
+		"one":                      *new(fq12).SetOne(),
+		"pairing":                  Pair(g1Gen, g2Gen).f,
+		"pairing of hashed points": Pair(new(G1Point).HashToPoint([]byte("abc")), new(G2Point).HashToPoint([]byte("def"))).f,
+	}
+	for name, input := range inputs {
+		t.Run(name, func(t *testing.T) {
+			// The squarings are decompressed together.
+			var want, got []*fq12
+			w, c := new(fq12).Set(&input), new(fq12).Set(&input)
+			for i := 0; i < 10; i++ {
+				w.CyclotomicSqr(w)
+				c.CyclotomicSqrCompressed(c)
+				want, got = append(want, new(fq12).Set(w)), append(got, new(fq12).Set(c))
+			}
+			fq12Decompress(got)
+			for i := range want {
+				if *got[i] != *want[i] {
+					t.Fatalf("[%d] expected: %v, got: %v", i, want[i], got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFq12CyclotomicExp(t *testing.T) {
+	x := Pair(g1Gen, g2Gen).f
+	tests := map[string]struct {
+		exp []uint64
+	}{
+		"zero":    {exp: []uint64{0}},
+		"empty":   {exp: nil},
+		"one":     {exp: []uint64{1}},
+		"two":     {exp: []uint64{2}},
+		"u":       {exp: []uint64{bigU.Uint64()}},
+		"r - 1":   {exp: []uint64{0xffffffff00000000, 0x53bda402fffe5bfe, 0x3339d80809a1d805, 0x73eda753299d7d48}},
+		"leading": {exp: []uint64{0, 1 << 63, 0}},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			want := new(fq12).Exp(&x, wordsToInt(tc.exp))
+			got := new(fq12).CyclotomicExp(&x, tc.exp)
+			if *got != *want {
+				t.Fatalf("expected: %v, got: %v", want, got)
+			}
+		})
+	}
+}
+
 func TestFq12Inv(t *testing.T) {
 	tests := map[string]struct {
 		input, want fq12
@@ -113,8 +113,8 @@ func (z *fq2) Inv(x *fq2) *fq2 {
 	// z0 = x0 * t0
 	// z1 = - x1 * t0
 	t0, t1 := new(fq), new(fq)
-	fqMul(t0, &x.c0, &x.c0)
-	fqMul(t1, &x.c1, &x.c1)
+	fqSqr(t0, &x.c0)
+	fqSqr(t1, &x.c1)
 	fqAdd(t0, t0, t1)
 	fqInv(t0, t0)
 	fqMul(&z.c0, &x.c0, t0)
@@ -124,8 +124,98 @@ func (z *fq2) Inv(x *fq2) *fq2 {
 	return z
 }
 
+// Sqrt sets z to a square root of x and reports whether it exists. If x is not
+// a square, z is left unchanged. Sqrt runs in constant time.
+// See https://eprint.iacr.org/2012/685.pdf - Algorithm 9; q ≡ 3 (mod 4)
+func (z *fq2) Sqrt(x *fq2) bool {
+	one := new(fq2).SetOne()
+	negOne := new(fq2).Neg(one)
+
+	// a1 = x^((q-3)/4), α = a1² x and a0 = α^q α.
+	a1, alpha, a0, x0 := new(fq2), new(fq2), new(fq2), new(fq2)
+	a1.Exp(x, qMinusThreeOverFour)
+	alpha.Sqr(a1)
+	alpha.Mul(alpha, x)
+	a0.Frobenius(alpha, 1)
+	a0.Mul(a0, alpha)
+	x0.Mul(a1, x)
+
+	// z = u x0 if α = -1 and (1 + α)^((q-1)/2) x0 otherwise.
+	iX0, b := new(fq2), new(fq2)
+	fqNeg(&iX0.c0, &x0.c1)
+	iX0.c1.Set(&x0.c0)
+	b.Add(one, alpha)
+	b.Exp(b, qMinusOneOverTwo)
+	b.Mul(b, x0)
+	fq2CMov(b, b, iX0, fq2Equal(alpha, negOne))
+
+	// x is a square unless a0 = -1.
+	isSquare := fq2Equal(a0, negOne) ^ 1
+	fq2CMov(z, z, b, isSquare)
+
+	return isSquare == 1
+}
+
 func (z *fq2) Frobenius(x *fq2, power uint64) *fq2 {
 	z.c0.Set(&x.c0)
 	fqMul(&z.c1, &x.c1, frobFq2C1[power%2])
 	return z
 }
+
+// Exp sets z=x**y, where y is given as little-endian 64 bit words, and
+// returns z.
+func (z *fq2) Exp(x *fq2, y []uint64) *fq2 {
+	ret := new(fq2).SetOne()
+	base := *x
+	for _, word := range y {
+		for j := uint(0); j < wordSize; j++ {
+			if (word & (1 << j)) != 0 {
+				ret.Mul(ret, &base)
+			}
+			base.Sqr(&base)
+		}
+	}
+
+	return z.Set(ret)
+}
+
+// fq2CMov sets z to y if b is 1 and to x if b is 0, in constant time.
+func fq2CMov(z, x, y *fq2, b uint64) {
+	fqCMov(&z.c0, &x.c0, &y.c0, b)
+	fqCMov(&z.c1, &x.c1, &y.c1, b)
+}
+
+// fq2Equal returns 1 if x is equal to y and 0 otherwise, in constant time.
+func fq2Equal(x, y *fq2) uint64 {
+	return fqEqual(&x.c0, &y.c0) & fqEqual(&x.c1, &y.c1)
+}
+
+// fq2IsZero returns 1 if x is equal to 0 and 0 otherwise, in constant time.
+func fq2IsZero(x *fq2) uint64 {
+	return fqIsZero(&x.c0) & fqIsZero(&x.c1)
+}
+
+// fq2IsSquare returns 1 if x is a square in Fq² and 0 otherwise, in constant
+// time. x is a square if and only if its norm x0² + x1² is a square in Fq.
+func fq2IsSquare(x *fq2) uint64 {
+	n, t := new(fq), new(fq)
+	fqSqr(n, &x.c0)
+	fqSqr(t, &x.c1)
+	fqAdd(n, n, t)
+	return fqIsSquare(n)
+}
+
+// fq2IsLexLargest reports whether x is lexicographically larger than -x, that
+// is, whether c1, or c0 if c1 is 0, is larger than (q-1)/2.
+func fq2IsLexLargest(x *fq2) bool {
+	if x.c1 != (fq{}) {
+		return fqIsLexLargest(&x.c1)
+	}
+	return fqIsLexLargest(&x.c0)
+}
+
+// fq2Sgn0 returns the sign of x, the parity of c0 or, if c0 is 0, of c1.
+// See https://www.rfc-editor.org/rfc/rfc9380.html#section-4.1.
+func fq2Sgn0(x *fq2) uint64 {
+	return fqSgn0(&x.c0) | (fqIsZero(&x.c0) & fqSgn0(&x.c1))
+}
@@ -1,6 +1,7 @@
 package bls12
 
 import (
+	"math/big"
 	"testing"
 )
 
@@ -329,3 +330,119 @@ func TestFq2Frobenius(t *testing.T) {
 		})
 	}
 }
+
+// fq2Inputs are elements of Fq², c0 + c1 u, given in base 10. They include
+// both squares and non-squares.
+var fq2Inputs = map[string][2]string{
+	"0":         {"0", "0"},
+	"1":         {"1", "0"},
+	"-1":        {"4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559786", "0"},
+	"u":         {"0", "1"},
+	"ξ = 1 + u": {"1", "1"},
+	"-(2 + u)":  {"4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559785", "4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559786"},
+	"3 + 4u":    {"3", "4"},
+	"large 1":   {"2973677408986561043442465346520108879172042883009249989176415018091420807192182638567116318576472649347015917690530", "1028732146235106349975324479215795277384839936929757896155643118032610843298655225875571310552543014690878354869257"},
+	"large 2":   {"1015919005498129635886032702454337503112659152043614931979881174103627376789972962005013361970813319613593700736144", "1244231661155348484223428017511856347821538750986231559855759541903146219579071812422210818684355842447591283616181"},
+	"large 3":   {"889424345604814976315064405719089812568196182208668418962679585805340366775741747653930584250892369786198727235542", "3557697382419259905260257622876359250272784728834673675850718343221361467102966990615722337003569479144794908942033"},
+}
+
+// newFq2 returns the element c0 + c1 u of Fq², given in base 10, along with
+// its coefficients as big integers.
+func newFq2(c [2]string) (fq2, *big.Int, *big.Int) {
+	c0, _ := new(big.Int).SetString(c[0], 10)
+	c1, _ := new(big.Int).SetString(c[1], 10)
+	var x fq2
+	x.c0.SetInt(c0)
+	x.c1.SetInt(c1)
+	return x, c0, c1
+}
+
+// bigFq2IsSquare reports whether c0 + c1 u is a square in Fq², using the
+// Jacobi symbol of its norm c0² + c1².
+func bigFq2IsSquare(c0, c1 *big.Int) bool {
+	n := new(big.Int).Mul(c0, c0)
+	n.Add(n, new(big.Int).Mul(c1, c1))
+	n.Mod(n, q)
+	return big.Jacobi(n, q) >= 0
+}
+
+func TestFq2Sqrt(t *testing.T) {
+	for name, input := range fq2Inputs {
+		t.Run(name, func(t *testing.T) {
+			x, c0, c1 := newFq2(input)
+			want := bigFq2IsSquare(c0, c1)
+			before := fq2{c0: *new(fq).SetUint64(5), c1: *new(fq).SetUint64(6)}
+			got := new(fq2).Set(&before)
+			ok := got.Sqrt(&x)
+			if ok != want {
+				t.Fatalf("expected: %v, got: %v", want, ok)
+			}
+			if !ok {
+				if *got != before {
+					t.Fatalf("expected z to be unchanged: %v, got: %v", before, got)
+				}
+				return
+			}
+			// (z0 + z1 u)² = z0² - z1² + 2 z0 z1 u
+			z0, z1 := got.c0.Int(), got.c1.Int()
+			sqr0 := new(big.Int).Mul(z0, z0)
+			sqr0.Sub(sqr0, new(big.Int).Mul(z1, z1))
+			sqr0.Mod(sqr0, q)
+			sqr1 := new(big.Int).Mul(z0, z1)
+			sqr1.Lsh(sqr1, 1)
+			sqr1.Mod(sqr1, q)
+			if sqr0.Cmp(c0) != 0 || sqr1.Cmp(c1) != 0 {
+				t.Fatalf("expected: %v + %vu, got: %v + %vu", c0, c1, sqr0, sqr1)
+			}
+		})
+	}
+}
+
+func TestFq2SqrtOfSquare(t *testing.T) {
+	for name, input := range fq2Inputs {
+		t.Run(name, func(t *testing.T) {
+			x, _, _ := newFq2(input)
+			sqr := new(fq2).Sqr(&x)
+			got := new(fq2)
+			if !got.Sqrt(sqr) {
+				t.Fatalf("expected a square root of %v", sqr)
+			}
+			if *got != x && *got != *new(fq2).Neg(&x) {
+				t.Fatalf("expected: ±%v, got: %v", x, got)
+			}
+		})
+	}
+}
+
+func TestFq2IsSquare(t *testing.T) {
+	for name, input := range fq2Inputs {
+		t.Run(name, func(t *testing.T) {
+			x, c0, c1 := newFq2(input)
+			var want uint64
+			if bigFq2IsSquare(c0, c1) {
+				want = 1
+			}
+			got := fq2IsSquare(&x)
+			if got != want {
+				t.Fatalf("expected: %v, got: %v", want, got)
+			}
+		})
+	}
+}
+
+func TestFq2Sgn0(t *testing.T) {
+	for name, input := range fq2Inputs {
+		t.Run(name, func(t *testing.T) {
+			x, c0, c1 := newFq2(input)
+			// sgn0(x) = sgn0(c0) OR (c0 == 0 AND sgn0(c1))
+			want := uint64(c0.Bit(0))
+			if c0.Sign() == 0 {
+				want = uint64(c1.Bit(0))
+			}
+			got := fq2Sgn0(&x)
+			if got != want {
+				t.Fatalf("expected: %v, got: %v", want, got)
+			}
+		})
+	}
+}
@@ -214,3 +214,10 @@ func (z *fq6) Frobenius(x *fq6, power uint64) *fq6 {
 
 	return z.Set(ret)
 }
+
+// fq6CMov sets z to y if b is 1 and to x if b is 0, in constant time.
+func fq6CMov(z, x, y *fq6, b uint64) {
+	fq2CMov(&z.c0, &x.c0, &y.c0, b)
+	fq2CMov(&z.c1, &x.c1, &y.c1, b)
+	fq2CMov(&z.c2, &x.c2, &y.c2, b)
+}
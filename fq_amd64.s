@@ -1169,3 +1169,946 @@ out:
 	MOVQ R10, 32(CX)
 	MOVQ R11, 40(CX)
 	RET
+
+// func fqSqr(z *[6]uint64, x *[6]uint64)
+TEXT ·fqSqr(SB), $96-16
+	MOVQ    x+8(FP), CX
+	CMPB    ·hasBMI2+0(SB), $0x00
+	JE      fallback
+	MOVQ    (CX), DX
+	MULXQ   8(CX), BP, SI
+	MULXQ   16(CX), AX, DI
+	ADDQ    AX, SI
+	ADCQ    $0x00, DI
+	MULXQ   24(CX), AX, R8
+	ADDQ    AX, DI
+	ADCQ    $0x00, R8
+	MULXQ   32(CX), AX, R9
+	ADDQ    AX, R8
+	ADCQ    $0x00, R9
+	MULXQ   40(CX), AX, R10
+	ADDQ    AX, R9
+	ADCQ    $0x00, R10
+	MOVQ    BP, 8(SP)
+	MOVQ    SI, 16(SP)
+	MOVQ    DI, 24(SP)
+	MOVQ    R8, 32(SP)
+	MOVQ    R9, 40(SP)
+	MOVQ    R10, 48(SP)
+	MOVQ    8(CX), DX
+	MULXQ   16(CX), BP, SI
+	MULXQ   24(CX), AX, DI
+	ADDQ    AX, SI
+	ADCQ    $0x00, DI
+	MULXQ   32(CX), AX, R8
+	ADDQ    AX, DI
+	ADCQ    $0x00, R8
+	MULXQ   40(CX), AX, R9
+	ADDQ    AX, R8
+	ADCQ    $0x00, R9
+	ADDQ    24(SP), BP
+	ADCQ    32(SP), SI
+	ADCQ    40(SP), DI
+	ADCQ    48(SP), R8
+	ADCQ    $0x00, R9
+	MOVQ    BP, 24(SP)
+	MOVQ    SI, 32(SP)
+	MOVQ    DI, 40(SP)
+	MOVQ    R8, 48(SP)
+	MOVQ    R9, 56(SP)
+	MOVQ    16(CX), DX
+	MULXQ   24(CX), BP, SI
+	MULXQ   32(CX), AX, DI
+	ADDQ    AX, SI
+	ADCQ    $0x00, DI
+	MULXQ   40(CX), AX, R8
+	ADDQ    AX, DI
+	ADCQ    $0x00, R8
+	ADDQ    40(SP), BP
+	ADCQ    48(SP), SI
+	ADCQ    56(SP), DI
+	ADCQ    $0x00, R8
+	MOVQ    BP, 40(SP)
+	MOVQ    SI, 48(SP)
+	MOVQ    DI, 56(SP)
+	MOVQ    R8, 64(SP)
+	MOVQ    24(CX), DX
+	MULXQ   32(CX), BP, SI
+	MULXQ   40(CX), AX, DI
+	ADDQ    AX, SI
+	ADCQ    $0x00, DI
+	ADDQ    56(SP), BP
+	ADCQ    64(SP), SI
+	ADCQ    $0x00, DI
+	MOVQ    BP, 56(SP)
+	MOVQ    SI, 64(SP)
+	MOVQ    DI, 72(SP)
+	MOVQ    32(CX), DX
+	MULXQ   40(CX), BP, SI
+	ADDQ    72(SP), BP
+	ADCQ    $0x00, SI
+	MOVQ    BP, 72(SP)
+	MOVQ    SI, 80(SP)
+	XORQ    BP, BP
+	MOVQ    8(SP), SI
+	MOVQ    16(SP), DI
+	MOVQ    24(SP), R8
+	MOVQ    32(SP), R9
+	MOVQ    40(SP), R10
+	ADDQ    SI, SI
+	ADCQ    DI, DI
+	ADCQ    R8, R8
+	ADCQ    R9, R9
+	ADCQ    R10, R10
+	MOVQ    $0x00000000, R12
+	ADCQ    $0x00, R12
+	XORQ    R11, R11
+	MOVQ    (CX), DX
+	MULXQ   DX, AX, BX
+	ADDQ    R11, AX
+	ADCQ    $0x00, BX
+	ADDQ    AX, BP
+	ADCQ    BX, SI
+	MOVQ    $0x00000000, R11
+	ADCQ    $0x00, R11
+	MOVQ    8(CX), DX
+	MULXQ   DX, AX, BX
+	ADDQ    R11, AX
+	ADCQ    $0x00, BX
+	ADDQ    AX, DI
+	ADCQ    BX, R8
+	MOVQ    $0x00000000, R11
+	ADCQ    $0x00, R11
+	MOVQ    16(CX), DX
+	MULXQ   DX, AX, BX
+	ADDQ    R11, AX
+	ADCQ    $0x00, BX
+	ADDQ    AX, R9
+	ADCQ    BX, R10
+	MOVQ    $0x00000000, R11
+	ADCQ    $0x00, R11
+	MOVQ    BP, (SP)
+	MOVQ    SI, 8(SP)
+	MOVQ    DI, 16(SP)
+	MOVQ    R8, 24(SP)
+	MOVQ    R9, 32(SP)
+	MOVQ    R10, 40(SP)
+	MOVQ    48(SP), BP
+	MOVQ    56(SP), SI
+	MOVQ    64(SP), DI
+	MOVQ    72(SP), R8
+	MOVQ    80(SP), R9
+	XORQ    R10, R10
+	ADDQ    BP, BP
+	ADCQ    SI, SI
+	ADCQ    DI, DI
+	ADCQ    R8, R8
+	ADCQ    R9, R9
+	ADCQ    R10, R10
+	ORQ     R12, BP
+	MOVQ    24(CX), DX
+	MULXQ   DX, AX, BX
+	ADDQ    R11, AX
+	ADCQ    $0x00, BX
+	ADDQ    AX, BP
+	ADCQ    BX, SI
+	MOVQ    $0x00000000, R11
+	ADCQ    $0x00, R11
+	MOVQ    32(CX), DX
+	MULXQ   DX, AX, BX
+	ADDQ    R11, AX
+	ADCQ    $0x00, BX
+	ADDQ    AX, DI
+	ADCQ    BX, R8
+	MOVQ    $0x00000000, R11
+	ADCQ    $0x00, R11
+	MOVQ    40(CX), DX
+	MULXQ   DX, AX, BX
+	ADDQ    R11, AX
+	ADCQ    $0x00, BX
+	ADDQ    AX, R9
+	ADCQ    BX, R10
+	MOVQ    $0x00000000, R11
+	ADCQ    $0x00, R11
+	MOVQ    BP, 48(SP)
+	MOVQ    SI, 56(SP)
+	MOVQ    DI, 64(SP)
+	MOVQ    R8, 72(SP)
+	MOVQ    R9, 80(SP)
+	MOVQ    R10, 88(SP)
+	XORQ    R12, R12
+	MOVQ    $0x89f3fffcfffcfffd, DX
+	MULXQ   (SP), DX, AX
+	MULXQ   ·q64+0(SB), BP, SI
+	MULXQ   ·q64+8(SB), AX, DI
+	ADDQ    AX, SI
+	ADCQ    $0x00, DI
+	MULXQ   ·q64+16(SB), AX, R8
+	ADDQ    AX, DI
+	ADCQ    $0x00, R8
+	MULXQ   ·q64+24(SB), AX, R9
+	ADDQ    AX, R8
+	ADCQ    $0x00, R9
+	MULXQ   ·q64+32(SB), AX, R10
+	ADDQ    AX, R9
+	ADCQ    $0x00, R10
+	MULXQ   ·q64+40(SB), AX, BX
+	ADDQ    AX, R10
+	ADCQ    $0x00, BX
+	ADDQ    (SP), BP
+	ADCQ    8(SP), SI
+	ADCQ    16(SP), DI
+	ADCQ    24(SP), R8
+	ADCQ    32(SP), R9
+	ADCQ    40(SP), R10
+	ADCQ    $0x00, BX
+	ADDQ    R12, BX
+	XORQ    R12, R12
+	ADDQ    48(SP), BX
+	ADCQ    $0x00, R12
+	MOVQ    BX, 48(SP)
+	MOVQ    SI, 8(SP)
+	MOVQ    DI, 16(SP)
+	MOVQ    R8, 24(SP)
+	MOVQ    R9, 32(SP)
+	MOVQ    R10, 40(SP)
+	MOVQ    $0x89f3fffcfffcfffd, DX
+	MULXQ   8(SP), DX, AX
+	MULXQ   ·q64+0(SB), BP, SI
+	MULXQ   ·q64+8(SB), AX, DI
+	ADDQ    AX, SI
+	ADCQ    $0x00, DI
+	MULXQ   ·q64+16(SB), AX, R8
+	ADDQ    AX, DI
+	ADCQ    $0x00, R8
+	MULXQ   ·q64+24(SB), AX, R9
+	ADDQ    AX, R8
+	ADCQ    $0x00, R9
+	MULXQ   ·q64+32(SB), AX, R10
+	ADDQ    AX, R9
+	ADCQ    $0x00, R10
+	MULXQ   ·q64+40(SB), AX, BX
+	ADDQ    AX, R10
+	ADCQ    $0x00, BX
+	ADDQ    8(SP), BP
+	ADCQ    16(SP), SI
+	ADCQ    24(SP), DI
+	ADCQ    32(SP), R8
+	ADCQ    40(SP), R9
+	ADCQ    48(SP), R10
+	ADCQ    $0x00, BX
+	ADDQ    R12, BX
+	XORQ    R12, R12
+	ADDQ    56(SP), BX
+	ADCQ    $0x00, R12
+	MOVQ    BX, 56(SP)
+	MOVQ    SI, 16(SP)
+	MOVQ    DI, 24(SP)
+	MOVQ    R8, 32(SP)
+	MOVQ    R9, 40(SP)
+	MOVQ    R10, 48(SP)
+	MOVQ    $0x89f3fffcfffcfffd, DX
+	MULXQ   16(SP), DX, AX
+	MULXQ   ·q64+0(SB), BP, SI
+	MULXQ   ·q64+8(SB), AX, DI
+	ADDQ    AX, SI
+	ADCQ    $0x00, DI
+	MULXQ   ·q64+16(SB), AX, R8
+	ADDQ    AX, DI
+	ADCQ    $0x00, R8
+	MULXQ   ·q64+24(SB), AX, R9
+	ADDQ    AX, R8
+	ADCQ    $0x00, R9
+	MULXQ   ·q64+32(SB), AX, R10
+	ADDQ    AX, R9
+	ADCQ    $0x00, R10
+	MULXQ   ·q64+40(SB), AX, BX
+	ADDQ    AX, R10
+	ADCQ    $0x00, BX
+	ADDQ    16(SP), BP
+	ADCQ    24(SP), SI
+	ADCQ    32(SP), DI
+	ADCQ    40(SP), R8
+	ADCQ    48(SP), R9
+	ADCQ    56(SP), R10
+	ADCQ    $0x00, BX
+	ADDQ    R12, BX
+	XORQ    R12, R12
+	ADDQ    64(SP), BX
+	ADCQ    $0x00, R12
+	MOVQ    BX, 64(SP)
+	MOVQ    SI, 24(SP)
+	MOVQ    DI, 32(SP)
+	MOVQ    R8, 40(SP)
+	MOVQ    R9, 48(SP)
+	MOVQ    R10, 56(SP)
+	MOVQ    $0x89f3fffcfffcfffd, DX
+	MULXQ   24(SP), DX, AX
+	MULXQ   ·q64+0(SB), BP, SI
+	MULXQ   ·q64+8(SB), AX, DI
+	ADDQ    AX, SI
+	ADCQ    $0x00, DI
+	MULXQ   ·q64+16(SB), AX, R8
+	ADDQ    AX, DI
+	ADCQ    $0x00, R8
+	MULXQ   ·q64+24(SB), AX, R9
+	ADDQ    AX, R8
+	ADCQ    $0x00, R9
+	MULXQ   ·q64+32(SB), AX, R10
+	ADDQ    AX, R9
+	ADCQ    $0x00, R10
+	MULXQ   ·q64+40(SB), AX, BX
+	ADDQ    AX, R10
+	ADCQ    $0x00, BX
+	ADDQ    24(SP), BP
+	ADCQ    32(SP), SI
+	ADCQ    40(SP), DI
+	ADCQ    48(SP), R8
+	ADCQ    56(SP), R9
+	ADCQ    64(SP), R10
+	ADCQ    $0x00, BX
+	ADDQ    R12, BX
+	XORQ    R12, R12
+	ADDQ    72(SP), BX
+	ADCQ    $0x00, R12
+	MOVQ    BX, 72(SP)
+	MOVQ    SI, 32(SP)
+	MOVQ    DI, 40(SP)
+	MOVQ    R8, 48(SP)
+	MOVQ    R9, 56(SP)
+	MOVQ    R10, 64(SP)
+	MOVQ    $0x89f3fffcfffcfffd, DX
+	MULXQ   32(SP), DX, AX
+	MULXQ   ·q64+0(SB), BP, SI
+	MULXQ   ·q64+8(SB), AX, DI
+	ADDQ    AX, SI
+	ADCQ    $0x00, DI
+	MULXQ   ·q64+16(SB), AX, R8
+	ADDQ    AX, DI
+	ADCQ    $0x00, R8
+	MULXQ   ·q64+24(SB), AX, R9
+	ADDQ    AX, R8
+	ADCQ    $0x00, R9
+	MULXQ   ·q64+32(SB), AX, R10
+	ADDQ    AX, R9
+	ADCQ    $0x00, R10
+	MULXQ   ·q64+40(SB), AX, BX
+	ADDQ    AX, R10
+	ADCQ    $0x00, BX
+	ADDQ    32(SP), BP
+	ADCQ    40(SP), SI
+	ADCQ    48(SP), DI
+	ADCQ    56(SP), R8
+	ADCQ    64(SP), R9
+	ADCQ    72(SP), R10
+	ADCQ    $0x00, BX
+	ADDQ    R12, BX
+	XORQ    R12, R12
+	ADDQ    80(SP), BX
+	ADCQ    $0x00, R12
+	MOVQ    BX, 80(SP)
+	MOVQ    SI, 40(SP)
+	MOVQ    DI, 48(SP)
+	MOVQ    R8, 56(SP)
+	MOVQ    R9, 64(SP)
+	MOVQ    R10, 72(SP)
+	MOVQ    $0x89f3fffcfffcfffd, DX
+	MULXQ   40(SP), DX, AX
+	MULXQ   ·q64+0(SB), BP, SI
+	MULXQ   ·q64+8(SB), AX, DI
+	ADDQ    AX, SI
+	ADCQ    $0x00, DI
+	MULXQ   ·q64+16(SB), AX, R8
+	ADDQ    AX, DI
+	ADCQ    $0x00, R8
+	MULXQ   ·q64+24(SB), AX, R9
+	ADDQ    AX, R8
+	ADCQ    $0x00, R9
+	MULXQ   ·q64+32(SB), AX, R10
+	ADDQ    AX, R9
+	ADCQ    $0x00, R10
+	MULXQ   ·q64+40(SB), AX, BX
+	ADDQ    AX, R10
+	ADCQ    $0x00, BX
+	ADDQ    40(SP), BP
+	ADCQ    48(SP), SI
+	ADCQ    56(SP), DI
+	ADCQ    64(SP), R8
+	ADCQ    72(SP), R9
+	ADCQ    80(SP), R10
+	ADCQ    $0x00, BX
+	ADDQ    R12, BX
+	XORQ    R12, R12
+	ADDQ    88(SP), BX
+	ADCQ    $0x00, R12
+	MOVQ    BX, 88(SP)
+	MOVQ    SI, 48(SP)
+	MOVQ    DI, 56(SP)
+	MOVQ    R8, 64(SP)
+	MOVQ    R9, 72(SP)
+	MOVQ    R10, 80(SP)
+	MOVQ    48(SP), BP
+	MOVQ    56(SP), SI
+	MOVQ    64(SP), DI
+	MOVQ    72(SP), R8
+	MOVQ    80(SP), R9
+	MOVQ    88(SP), R10
+	MOVQ    BP, AX
+	MOVQ    SI, CX
+	MOVQ    DI, DX
+	MOVQ    R8, BX
+	MOVQ    R9, R11
+	MOVQ    R10, R12
+	SUBQ    ·q64+0(SB), AX
+	SBBQ    ·q64+8(SB), CX
+	SBBQ    ·q64+16(SB), DX
+	SBBQ    ·q64+24(SB), BX
+	SBBQ    ·q64+32(SB), R11
+	SBBQ    ·q64+40(SB), R12
+	CMOVQCC AX, BP
+	CMOVQCC CX, SI
+	CMOVQCC DX, DI
+	CMOVQCC BX, R8
+	CMOVQCC R11, R9
+	CMOVQCC R12, R10
+	JMP     out
+
+fallback:
+	MOVQ    (CX), AX
+	MULQ    8(CX)
+	MOVQ    AX, BP
+	MOVQ    DX, SI
+	MOVQ    (CX), AX
+	MULQ    16(CX)
+	ADDQ    AX, SI
+	ADCQ    $0x00, DX
+	MOVQ    DX, DI
+	MOVQ    (CX), AX
+	MULQ    24(CX)
+	ADDQ    AX, DI
+	ADCQ    $0x00, DX
+	MOVQ    DX, R8
+	MOVQ    (CX), AX
+	MULQ    32(CX)
+	ADDQ    AX, R8
+	ADCQ    $0x00, DX
+	MOVQ    DX, R9
+	MOVQ    (CX), AX
+	MULQ    40(CX)
+	ADDQ    AX, R9
+	ADCQ    $0x00, DX
+	MOVQ    DX, R10
+	MOVQ    BP, 8(SP)
+	MOVQ    SI, 16(SP)
+	MOVQ    DI, 24(SP)
+	MOVQ    R8, 32(SP)
+	MOVQ    R9, 40(SP)
+	MOVQ    R10, 48(SP)
+	MOVQ    8(CX), AX
+	MULQ    16(CX)
+	MOVQ    AX, BP
+	MOVQ    DX, SI
+	MOVQ    8(CX), AX
+	MULQ    24(CX)
+	ADDQ    AX, SI
+	ADCQ    $0x00, DX
+	MOVQ    DX, DI
+	MOVQ    8(CX), AX
+	MULQ    32(CX)
+	ADDQ    AX, DI
+	ADCQ    $0x00, DX
+	MOVQ    DX, R8
+	MOVQ    8(CX), AX
+	MULQ    40(CX)
+	ADDQ    AX, R8
+	ADCQ    $0x00, DX
+	MOVQ    DX, R9
+	ADDQ    24(SP), BP
+	ADCQ    32(SP), SI
+	ADCQ    40(SP), DI
+	ADCQ    48(SP), R8
+	ADCQ    $0x00, R9
+	MOVQ    BP, 24(SP)
+	MOVQ    SI, 32(SP)
+	MOVQ    DI, 40(SP)
+	MOVQ    R8, 48(SP)
+	MOVQ    R9, 56(SP)
+	MOVQ    16(CX), AX
+	MULQ    24(CX)
+	MOVQ    AX, BP
+	MOVQ    DX, SI
+	MOVQ    16(CX), AX
+	MULQ    32(CX)
+	ADDQ    AX, SI
+	ADCQ    $0x00, DX
+	MOVQ    DX, DI
+	MOVQ    16(CX), AX
+	MULQ    40(CX)
+	ADDQ    AX, DI
+	ADCQ    $0x00, DX
+	MOVQ    DX, R8
+	ADDQ    40(SP), BP
+	ADCQ    48(SP), SI
+	ADCQ    56(SP), DI
+	ADCQ    $0x00, R8
+	MOVQ    BP, 40(SP)
+	MOVQ    SI, 48(SP)
+	MOVQ    DI, 56(SP)
+	MOVQ    R8, 64(SP)
+	MOVQ    24(CX), AX
+	MULQ    32(CX)
+	MOVQ    AX, BP
+	MOVQ    DX, SI
+	MOVQ    24(CX), AX
+	MULQ    40(CX)
+	ADDQ    AX, SI
+	ADCQ    $0x00, DX
+	MOVQ    DX, DI
+	ADDQ    56(SP), BP
+	ADCQ    64(SP), SI
+	ADCQ    $0x00, DI
+	MOVQ    BP, 56(SP)
+	MOVQ    SI, 64(SP)
+	MOVQ    DI, 72(SP)
+	MOVQ    32(CX), AX
+	MULQ    40(CX)
+	MOVQ    AX, BP
+	MOVQ    DX, SI
+	ADDQ    72(SP), BP
+	ADCQ    $0x00, SI
+	MOVQ    BP, 72(SP)
+	MOVQ    SI, 80(SP)
+	XORQ    BP, BP
+	MOVQ    8(SP), SI
+	MOVQ    16(SP), DI
+	MOVQ    24(SP), R8
+	MOVQ    32(SP), R9
+	MOVQ    40(SP), R10
+	ADDQ    SI, SI
+	ADCQ    DI, DI
+	ADCQ    R8, R8
+	ADCQ    R9, R9
+	ADCQ    R10, R10
+	MOVQ    $0x00000000, R12
+	ADCQ    $0x00, R12
+	XORQ    R11, R11
+	MOVQ    (CX), AX
+	MULQ    AX
+	ADDQ    R11, AX
+	ADCQ    $0x00, DX
+	ADDQ    AX, BP
+	ADCQ    DX, SI
+	MOVQ    $0x00000000, R11
+	ADCQ    $0x00, R11
+	MOVQ    8(CX), AX
+	MULQ    AX
+	ADDQ    R11, AX
+	ADCQ    $0x00, DX
+	ADDQ    AX, DI
+	ADCQ    DX, R8
+	MOVQ    $0x00000000, R11
+	ADCQ    $0x00, R11
+	MOVQ    16(CX), AX
+	MULQ    AX
+	ADDQ    R11, AX
+	ADCQ    $0x00, DX
+	ADDQ    AX, R9
+	ADCQ    DX, R10
+	MOVQ    $0x00000000, R11
+	ADCQ    $0x00, R11
+	MOVQ    BP, (SP)
+	MOVQ    SI, 8(SP)
+	MOVQ    DI, 16(SP)
+	MOVQ    R8, 24(SP)
+	MOVQ    R9, 32(SP)
+	MOVQ    R10, 40(SP)
+	MOVQ    48(SP), BP
+	MOVQ    56(SP), SI
+	MOVQ    64(SP), DI
+	MOVQ    72(SP), R8
+	MOVQ    80(SP), R9
+	XORQ    R10, R10
+	ADDQ    BP, BP
+	ADCQ    SI, SI
+	ADCQ    DI, DI
+	ADCQ    R8, R8
+	ADCQ    R9, R9
+	ADCQ    R10, R10
+	ORQ     R12, BP
+	MOVQ    24(CX), AX
+	MULQ    AX
+	ADDQ    R11, AX
+	ADCQ    $0x00, DX
+	ADDQ    AX, BP
+	ADCQ    DX, SI
+	MOVQ    $0x00000000, R11
+	ADCQ    $0x00, R11
+	MOVQ    32(CX), AX
+	MULQ    AX
+	ADDQ    R11, AX
+	ADCQ    $0x00, DX
+	ADDQ    AX, DI
+	ADCQ    DX, R8
+	MOVQ    $0x00000000, R11
+	ADCQ    $0x00, R11
+	MOVQ    40(CX), AX
+	MULQ    AX
+	ADDQ    R11, AX
+	ADCQ    $0x00, DX
+	ADDQ    AX, R9
+	ADCQ    DX, R10
+	MOVQ    $0x00000000, R11
+	ADCQ    $0x00, R11
+	MOVQ    BP, 48(SP)
+	MOVQ    SI, 56(SP)
+	MOVQ    DI, 64(SP)
+	MOVQ    R8, 72(SP)
+	MOVQ    R9, 80(SP)
+	MOVQ    R10, 88(SP)
+	XORQ    R12, R12
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    (SP)
+	MULQ    ·q64+0(SB)
+	MOVQ    AX, BP
+	MOVQ    DX, SI
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    (SP)
+	MULQ    ·q64+8(SB)
+	ADDQ    AX, SI
+	ADCQ    $0x00, DX
+	MOVQ    DX, DI
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    (SP)
+	MULQ    ·q64+16(SB)
+	ADDQ    AX, DI
+	ADCQ    $0x00, DX
+	MOVQ    DX, R8
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    (SP)
+	MULQ    ·q64+24(SB)
+	ADDQ    AX, R8
+	ADCQ    $0x00, DX
+	MOVQ    DX, R9
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    (SP)
+	MULQ    ·q64+32(SB)
+	ADDQ    AX, R9
+	ADCQ    $0x00, DX
+	MOVQ    DX, R10
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    (SP)
+	MULQ    ·q64+40(SB)
+	ADDQ    AX, R10
+	ADCQ    $0x00, DX
+	MOVQ    DX, R11
+	ADDQ    (SP), BP
+	ADCQ    8(SP), SI
+	ADCQ    16(SP), DI
+	ADCQ    24(SP), R8
+	ADCQ    32(SP), R9
+	ADCQ    40(SP), R10
+	ADCQ    $0x00, R11
+	ADDQ    R12, R11
+	XORQ    R12, R12
+	ADDQ    48(SP), R11
+	ADCQ    $0x00, R12
+	MOVQ    R11, 48(SP)
+	MOVQ    SI, 8(SP)
+	MOVQ    DI, 16(SP)
+	MOVQ    R8, 24(SP)
+	MOVQ    R9, 32(SP)
+	MOVQ    R10, 40(SP)
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    8(SP)
+	MULQ    ·q64+0(SB)
+	MOVQ    AX, BP
+	MOVQ    DX, SI
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    8(SP)
+	MULQ    ·q64+8(SB)
+	ADDQ    AX, SI
+	ADCQ    $0x00, DX
+	MOVQ    DX, DI
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    8(SP)
+	MULQ    ·q64+16(SB)
+	ADDQ    AX, DI
+	ADCQ    $0x00, DX
+	MOVQ    DX, R8
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    8(SP)
+	MULQ    ·q64+24(SB)
+	ADDQ    AX, R8
+	ADCQ    $0x00, DX
+	MOVQ    DX, R9
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    8(SP)
+	MULQ    ·q64+32(SB)
+	ADDQ    AX, R9
+	ADCQ    $0x00, DX
+	MOVQ    DX, R10
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    8(SP)
+	MULQ    ·q64+40(SB)
+	ADDQ    AX, R10
+	ADCQ    $0x00, DX
+	MOVQ    DX, R11
+	ADDQ    8(SP), BP
+	ADCQ    16(SP), SI
+	ADCQ    24(SP), DI
+	ADCQ    32(SP), R8
+	ADCQ    40(SP), R9
+	ADCQ    48(SP), R10
+	ADCQ    $0x00, R11
+	ADDQ    R12, R11
+	XORQ    R12, R12
+	ADDQ    56(SP), R11
+	ADCQ    $0x00, R12
+	MOVQ    R11, 56(SP)
+	MOVQ    SI, 16(SP)
+	MOVQ    DI, 24(SP)
+	MOVQ    R8, 32(SP)
+	MOVQ    R9, 40(SP)
+	MOVQ    R10, 48(SP)
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    16(SP)
+	MULQ    ·q64+0(SB)
+	MOVQ    AX, BP
+	MOVQ    DX, SI
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    16(SP)
+	MULQ    ·q64+8(SB)
+	ADDQ    AX, SI
+	ADCQ    $0x00, DX
+	MOVQ    DX, DI
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    16(SP)
+	MULQ    ·q64+16(SB)
+	ADDQ    AX, DI
+	ADCQ    $0x00, DX
+	MOVQ    DX, R8
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    16(SP)
+	MULQ    ·q64+24(SB)
+	ADDQ    AX, R8
+	ADCQ    $0x00, DX
+	MOVQ    DX, R9
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    16(SP)
+	MULQ    ·q64+32(SB)
+	ADDQ    AX, R9
+	ADCQ    $0x00, DX
+	MOVQ    DX, R10
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    16(SP)
+	MULQ    ·q64+40(SB)
+	ADDQ    AX, R10
+	ADCQ    $0x00, DX
+	MOVQ    DX, R11
+	ADDQ    16(SP), BP
+	ADCQ    24(SP), SI
+	ADCQ    32(SP), DI
+	ADCQ    40(SP), R8
+	ADCQ    48(SP), R9
+	ADCQ    56(SP), R10
+	ADCQ    $0x00, R11
+	ADDQ    R12, R11
+	XORQ    R12, R12
+	ADDQ    64(SP), R11
+	ADCQ    $0x00, R12
+	MOVQ    R11, 64(SP)
+	MOVQ    SI, 24(SP)
+	MOVQ    DI, 32(SP)
+	MOVQ    R8, 40(SP)
+	MOVQ    R9, 48(SP)
+	MOVQ    R10, 56(SP)
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    24(SP)
+	MULQ    ·q64+0(SB)
+	MOVQ    AX, BP
+	MOVQ    DX, SI
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    24(SP)
+	MULQ    ·q64+8(SB)
+	ADDQ    AX, SI
+	ADCQ    $0x00, DX
+	MOVQ    DX, DI
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    24(SP)
+	MULQ    ·q64+16(SB)
+	ADDQ    AX, DI
+	ADCQ    $0x00, DX
+	MOVQ    DX, R8
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    24(SP)
+	MULQ    ·q64+24(SB)
+	ADDQ    AX, R8
+	ADCQ    $0x00, DX
+	MOVQ    DX, R9
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    24(SP)
+	MULQ    ·q64+32(SB)
+	ADDQ    AX, R9
+	ADCQ    $0x00, DX
+	MOVQ    DX, R10
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    24(SP)
+	MULQ    ·q64+40(SB)
+	ADDQ    AX, R10
+	ADCQ    $0x00, DX
+	MOVQ    DX, R11
+	ADDQ    24(SP), BP
+	ADCQ    32(SP), SI
+	ADCQ    40(SP), DI
+	ADCQ    48(SP), R8
+	ADCQ    56(SP), R9
+	ADCQ    64(SP), R10
+	ADCQ    $0x00, R11
+	ADDQ    R12, R11
+	XORQ    R12, R12
+	ADDQ    72(SP), R11
+	ADCQ    $0x00, R12
+	MOVQ    R11, 72(SP)
+	MOVQ    SI, 32(SP)
+	MOVQ    DI, 40(SP)
+	MOVQ    R8, 48(SP)
+	MOVQ    R9, 56(SP)
+	MOVQ    R10, 64(SP)
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    32(SP)
+	MULQ    ·q64+0(SB)
+	MOVQ    AX, BP
+	MOVQ    DX, SI
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    32(SP)
+	MULQ    ·q64+8(SB)
+	ADDQ    AX, SI
+	ADCQ    $0x00, DX
+	MOVQ    DX, DI
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    32(SP)
+	MULQ    ·q64+16(SB)
+	ADDQ    AX, DI
+	ADCQ    $0x00, DX
+	MOVQ    DX, R8
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    32(SP)
+	MULQ    ·q64+24(SB)
+	ADDQ    AX, R8
+	ADCQ    $0x00, DX
+	MOVQ    DX, R9
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    32(SP)
+	MULQ    ·q64+32(SB)
+	ADDQ    AX, R9
+	ADCQ    $0x00, DX
+	MOVQ    DX, R10
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    32(SP)
+	MULQ    ·q64+40(SB)
+	ADDQ    AX, R10
+	ADCQ    $0x00, DX
+	MOVQ    DX, R11
+	ADDQ    32(SP), BP
+	ADCQ    40(SP), SI
+	ADCQ    48(SP), DI
+	ADCQ    56(SP), R8
+	ADCQ    64(SP), R9
+	ADCQ    72(SP), R10
+	ADCQ    $0x00, R11
+	ADDQ    R12, R11
+	XORQ    R12, R12
+	ADDQ    80(SP), R11
+	ADCQ    $0x00, R12
+	MOVQ    R11, 80(SP)
+	MOVQ    SI, 40(SP)
+	MOVQ    DI, 48(SP)
+	MOVQ    R8, 56(SP)
+	MOVQ    R9, 64(SP)
+	MOVQ    R10, 72(SP)
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    40(SP)
+	MULQ    ·q64+0(SB)
+	MOVQ    AX, BP
+	MOVQ    DX, SI
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    40(SP)
+	MULQ    ·q64+8(SB)
+	ADDQ    AX, SI
+	ADCQ    $0x00, DX
+	MOVQ    DX, DI
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    40(SP)
+	MULQ    ·q64+16(SB)
+	ADDQ    AX, DI
+	ADCQ    $0x00, DX
+	MOVQ    DX, R8
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    40(SP)
+	MULQ    ·q64+24(SB)
+	ADDQ    AX, R8
+	ADCQ    $0x00, DX
+	MOVQ    DX, R9
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    40(SP)
+	MULQ    ·q64+32(SB)
+	ADDQ    AX, R9
+	ADCQ    $0x00, DX
+	MOVQ    DX, R10
+	MOVQ    $0x89f3fffcfffcfffd, AX
+	MULQ    40(SP)
+	MULQ    ·q64+40(SB)
+	ADDQ    AX, R10
+	ADCQ    $0x00, DX
+	MOVQ    DX, R11
+	ADDQ    40(SP), BP
+	ADCQ    48(SP), SI
+	ADCQ    56(SP), DI
+	ADCQ    64(SP), R8
+	ADCQ    72(SP), R9
+	ADCQ    80(SP), R10
+	ADCQ    $0x00, R11
+	ADDQ    R12, R11
+	XORQ    R12, R12
+	ADDQ    88(SP), R11
+	ADCQ    $0x00, R12
+	MOVQ    R11, 88(SP)
+	MOVQ    SI, 48(SP)
+	MOVQ    DI, 56(SP)
+	MOVQ    R8, 64(SP)
+	MOVQ    R9, 72(SP)
+	MOVQ    R10, 80(SP)
+	MOVQ    48(SP), BP
+	MOVQ    56(SP), SI
+	MOVQ    64(SP), DI
+	MOVQ    72(SP), R8
+	MOVQ    80(SP), R9
+	MOVQ    88(SP), R10
+	MOVQ    BP, AX
+	MOVQ    SI, CX
+	MOVQ    DI, DX
+	MOVQ    R8, BX
+	MOVQ    R9, R11
+	MOVQ    R10, R12
+	SUBQ    ·q64+0(SB), AX
+	SBBQ    ·q64+8(SB), CX
+	SBBQ    ·q64+16(SB), DX
+	SBBQ    ·q64+24(SB), BX
+	SBBQ    ·q64+32(SB), R11
+	SBBQ    ·q64+40(SB), R12
+	CMOVQCC AX, BP
+	CMOVQCC CX, SI
+	CMOVQCC DX, DI
+	CMOVQCC BX, R8
+	CMOVQCC R11, R9
+	CMOVQCC R12, R10
+
+out:
+	MOVQ z+0(FP), CX
+	MOVQ BP, (CX)
+	MOVQ SI, 8(CX)
+	MOVQ DI, 16(CX)
+	MOVQ R8, 24(CX)
+	MOVQ R9, 32(CX)
+	MOVQ R10, 40(CX)
+	RET
@@ -1,152 +1,290 @@
-// +build arm64,!generic
+// +build arm64asm,!generic
+
+#include "textflag.h"
+
+// fqLoadQ loads q into R8..R13.
+#define fqLoadQ \
+	MOVD $·q64(SB), R1; \
+	LDP  0(R1), (R8, R9); \
+	LDP  16(R1), (R10, R11); \
+	LDP  32(R1), (R12, R13)
+
+// fqMod subtracts q, held in R8..R13, from t0..t5 if t0..t5 is at least q, in
+// constant time. u0..u5 are clobbered.
+#define fqMod(t0, t1, t2, t3, t4, t5, u0, u1, u2, u3, u4, u5) \
+	SUBS R8, t0, u0; \
+	SBCS R9, t1, u1; \
+	SBCS R10, t2, u2; \
+	SBCS R11, t3, u3; \
+	SBCS R12, t4, u4; \
+	SBCS R13, t5, u5; \
+	CSEL CS, u0, t0, t0; \
+	CSEL CS, u1, t1, t1; \
+	CSEL CS, u2, t2, t2; \
+	CSEL CS, u3, t3, t3; \
+	CSEL CS, u4, t4, t4; \
+	CSEL CS, u5, t5, t5
+
+// fqMulAdd sets t, R25 to t + a*b + R25. R23 and R24 hold the halves of the
+// product.
+#define fqMulAdd(a, b, t) \
+	MUL   b, a, R23; \
+	UMULH b, a, R24; \
+	ADDS  R23, t, t; \
+	ADC   ZR, R24, R24; \
+	ADDS  R25, t, t; \
+	ADC   ZR, R24, R25
+
+// fqMulRow adds a*(b0..b5) to t0..t5 and leaves the carry in R25.
+#define fqMulRow(a, b0, b1, b2, b3, b4, b5, t0, t1, t2, t3, t4, t5) \
+	MOVD ZR, R25; \
+	fqMulAdd(a, b0, t0); \
+	fqMulAdd(a, b1, t1); \
+	fqMulAdd(a, b2, t2); \
+	fqMulAdd(a, b3, t3); \
+	fqMulAdd(a, b4, t4); \
+	fqMulAdd(a, b5, t5)
+
+// fqMulRound adds x*yi, with x in R2..R7 and yi in R1, to t0..t7 and then m*q,
+// where m makes t0 0, so that t0..t7 can be shifted by a word.
+#define fqMulRound(t0, t1, t2, t3, t4, t5, t6, t7) \
+	fqMulRow(R1, R2, R3, R4, R5, R6, R7, t0, t1, t2, t3, t4, t5); \
+	ADDS R25, t6, t6; \
+	ADC  ZR, t7, t7; \
+	MOVD $0x89f3fffcfffcfffd, R1; \
+	MUL  R1, t0, R1; \
+	fqMulRow(R1, R8, R9, R10, R11, R12, R13, t0, t1, t2, t3, t4, t5); \
+	ADDS R25, t6, t6; \
+	ADC  ZR, t7, t7
+
+// fqREDCRound adds m*q to t0..t6, where m makes t0 0. qK64 is held in R22 and
+// the carry out of t6 is kept in R21 for the next round.
+#define fqREDCRound(t0, t1, t2, t3, t4, t5, t6) \
+	MUL  R22, t0, R1; \
+	fqMulRow(R1, R8, R9, R10, R11, R12, R13, t0, t1, t2, t3, t4, t5); \
+	ADDS R21, R25, R25; \
+	ADDS R25, t6, t6; \
+	ADC  ZR, ZR, R21
+
+#define fqStore(t0, t1, t2, t3, t4, t5) \
+	MOVD z+0(FP), R0; \
+	STP  (t0, t1), 0(R0); \
+	STP  (t2, t3), 16(R0); \
+	STP  (t4, t5), 32(R0)
 
 // func fqAdd(z *[6]uint64, x *[6]uint64, y *[6]uint64)
-TEXT ·fqAdd(SB), $0-24
-    MOVD x+8(FP), R0
-    MOVD (R0), R1
-    MOVD 8(R0), R2
-    MOVD 16(R0), R3
-    MOVD 24(R0), R4
-    MOVD 32(R0), R5
-    MOVD 40(R0), R6
-
-    MOVD(y+16), R0
-    MOVD (R0), R7
-    MOVD 8(R0), R8
-    MOVD 16(R0), R9
-    MOVD 24(R0), R10
-    MOVD 32(R0), R11
-    MOVD 40(R0), R12
-
-    ADDS R7, R1
-    ADCS R8, R2
-    ADCS R9, R3
-    ADCS R10, R4
-    ADCS R11, R5
-    ADCS R12, R6
-
-    MOVD ZR, R0
-    SUBS ·q64+0(SB), R1, R7
-	SBCQ ·q64+8(SB), R2, R8
-	SBCQ ·q64+16(SB), R3, R9
-	SBCQ ·q64+24(SB), R4, R10
-	SBCQ ·q64+32(SB), R5, R11
-	SBCQ ·q64+40(SB), R6, R12
-    SBCS  ZR, R0, R0
-
-    CSEL CS, R7, R1, R1
-    CSEL CS, R8, R2, R2
-    CSEL CS, R9, R3, R3
-    CSEL CS, R10, R4, R4
-    CSEL CS, R11, R5, R5
-    CSEL CS, R12, R6, R6
-
-    MOVD z+0(FP), R0
-    MOVD R1, (R0)
-    MOVD R2, 8(R0)
-    MOVD R3, 16(R0)
-    MOVD R4, 24(R0)
-    MOVD R5, 32(R0)
-    MOVD R6, 40(R0)
-    
-    RET
+TEXT ·fqAdd(SB), NOSPLIT, $0-24
+	MOVD x+8(FP), R0
+	LDP  0(R0), (R2, R3)
+	LDP  16(R0), (R4, R5)
+	LDP  32(R0), (R6, R7)
+	MOVD y+16(FP), R0
+	LDP  0(R0), (R14, R15)
+	LDP  16(R0), (R16, R17)
+	LDP  32(R0), (R19, R20)
+
+	ADDS R14, R2, R2
+	ADCS R15, R3, R3
+	ADCS R16, R4, R4
+	ADCS R17, R5, R5
+	ADCS R19, R6, R6
+	ADC  R20, R7, R7
+
+	fqLoadQ
+	fqMod(R2, R3, R4, R5, R6, R7, R14, R15, R16, R17, R19, R20)
+	fqStore(R2, R3, R4, R5, R6, R7)
+	RET
 
 // func fqNeg(z *[6]uint64, x *[6]uint64)
-TEXT ·fqNeg(SB), $0-16
-    MOVD x+8(FP), R0
-    MOVD (R0), R1
-    MOVD 8(R0), R2
-    MOVD 16(R0), R3
-    MOVD 24(R0), R4
-    MOVD 32(R0), R5
-    MOVD 40(R0), R6
-
-    MOVD ·q64+0(SB), R7
-    MOVD ·q64+8(SB), R8
-    MOVD ·q64+16(SB), R9
-    MOVD ·q64+24(SB), R10
-    MOVD ·q64+32(SB), R11
-    MOVD ·q64+40(SB), R12
-
-    SUBS R7, R1, R7
-    SBCS R8, R2, R8
-    SBCS R9, R3, R9
-    SBCS R10, R4, R10
-    SBCS R11, R5, R11
-    SBCS R12, R6, R12
-
-    CSEL CS, R7, R1, R1
-	CSEL CS, R8, R2, R2
-	CSEL CS, R9, R3, R3
-	CSEL CS, R10, R4, R4
-    CSEL CS, R11, R5, R5
-    CSEL CS, R12, R6, R6
-
-    MOVD z+0(FP), R0
-    MOVD R1, (R0)
-    MOVD R2, 8(R0)
-    MOVD R3, 16(R0)
-    MOVD R4, 24(R0)
-    MOVD R5, 32(R0)
-    MOVD R6, 40(R0)
-
-    RET
+TEXT ·fqNeg(SB), NOSPLIT, $0-16
+	MOVD x+8(FP), R0
+	LDP  0(R0), (R14, R15)
+	LDP  16(R0), (R16, R17)
+	LDP  32(R0), (R19, R20)
+
+	fqLoadQ
+	SUBS R14, R8, R2
+	SBCS R15, R9, R3
+	SBCS R16, R10, R4
+	SBCS R17, R11, R5
+	SBCS R19, R12, R6
+	SBC  R20, R13, R7
+
+	// -0 is q before the reduction.
+	fqMod(R2, R3, R4, R5, R6, R7, R14, R15, R16, R17, R19, R20)
+	fqStore(R2, R3, R4, R5, R6, R7)
+	RET
 
 // func fqSub(z *[6]uint64, x *[6]uint64, y *[6]uint64)
-TEXT ·fqSub(SB), $0-24
-    MOVD x+8(FP), R0
-    MOVD (R0), R1
-    MOVD 8(R0), R2
-    MOVD 16(R0), R3
-    MOVD 24(R0), R4
-    MOVD 32(R0), R5
-    MOVD 40(R0), R6
-
-    MOVD(y+16), R0
-    MOVD (R0), R7
-    MOVD 8(R0), R8
-    MOVD 16(R0), R9
-    MOVD 24(R0), R10
-    MOVD 32(R0), R11
-    MOVD 40(R0), R12
-
-    SUBS R7, R1
-    SUBS R8, R2
-    SUBS R9, R3
-    SUBS R10, R4
-    SUBS R11, R5
-    SUBS R12, R6
-
-    MOVD ·q64+0(SB), R7
-    MOVD ·q64+8(SB), R8
-    MOVD ·q64+16(SB), R9
-    MOVD ·q64+24(SB), R10
-    MOVD ·q64+32(SB), R11
-    MOVD ·q64+40(SB), R12
-
-    CSEL CS, ZR, R7, R7
+TEXT ·fqSub(SB), NOSPLIT, $0-24
+	MOVD x+8(FP), R0
+	LDP  0(R0), (R2, R3)
+	LDP  16(R0), (R4, R5)
+	LDP  32(R0), (R6, R7)
+	MOVD y+16(FP), R0
+	LDP  0(R0), (R14, R15)
+	LDP  16(R0), (R16, R17)
+	LDP  32(R0), (R19, R20)
+
+	SUBS R14, R2, R2
+	SBCS R15, R3, R3
+	SBCS R16, R4, R4
+	SBCS R17, R5, R5
+	SBCS R19, R6, R6
+	SBCS R20, R7, R7
+
+	// if x < y, then q is added back.
+	fqLoadQ
 	CSEL CS, ZR, R8, R8
 	CSEL CS, ZR, R9, R9
 	CSEL CS, ZR, R10, R10
-    CSEL CS, ZR, R11, R11
-    CSEL CS, ZR, R12, R12
-
-    ADDS R7, R1
-    ADCS R8, R2
-    ADCS R9, R3
-    ADCS R10, R4
-    ADCS R11, R5
-    ADCS R12, R6
-
-    MOVD z+0(FP), R0
-    MOVD R1, (R0)
-    MOVD R2, 8(R0)
-    MOVD R3, 16(R0)
-    MOVD R4, 24(R0)
-    MOVD R5, 32(R0)
-    MOVD R6, 40(R0)
-
-    RET
-
-TEXT ·fqMul(SB), $96-24
-    RET
\ No newline at end of file
+	CSEL CS, ZR, R11, R11
+	CSEL CS, ZR, R12, R12
+	CSEL CS, ZR, R13, R13
+	ADDS R8, R2, R2
+	ADCS R9, R3, R3
+	ADCS R10, R4, R4
+	ADCS R11, R5, R5
+	ADCS R12, R6, R6
+	ADC  R13, R7, R7
+
+	fqStore(R2, R3, R4, R5, R6, R7)
+	RET
+
+// func fqMul(z *[6]uint64, x *[6]uint64, y *[6]uint64)
+TEXT ·fqMul(SB), NOSPLIT, $0-24
+	MOVD x+8(FP), R0
+	LDP  0(R0), (R2, R3)
+	LDP  16(R0), (R4, R5)
+	LDP  32(R0), (R6, R7)
+	fqLoadQ
+	MOVD y+16(FP), R0
+	MOVD ZR, R14
+	MOVD ZR, R15
+	MOVD ZR, R16
+	MOVD ZR, R17
+	MOVD ZR, R19
+	MOVD ZR, R20
+	MOVD ZR, R21
+	MOVD ZR, R22
+
+	// the register of the lowest word, 0 after each round, becomes the
+	// highest word of the next round.
+	MOVD 0(R0), R1
+	fqMulRound(R14, R15, R16, R17, R19, R20, R21, R22)
+	MOVD 8(R0), R1
+	fqMulRound(R15, R16, R17, R19, R20, R21, R22, R14)
+	MOVD 16(R0), R1
+	fqMulRound(R16, R17, R19, R20, R21, R22, R14, R15)
+	MOVD 24(R0), R1
+	fqMulRound(R17, R19, R20, R21, R22, R14, R15, R16)
+	MOVD 32(R0), R1
+	fqMulRound(R19, R20, R21, R22, R14, R15, R16, R17)
+	MOVD 40(R0), R1
+	fqMulRound(R20, R21, R22, R14, R15, R16, R17, R19)
+
+	fqMod(R21, R22, R14, R15, R16, R17, R2, R3, R4, R5, R6, R7)
+	fqStore(R21, R22, R14, R15, R16, R17)
+	RET
+
+// func fqSqr(z *[6]uint64, x *[6]uint64)
+TEXT ·fqSqr(SB), NOSPLIT, $0-16
+	MOVD x+8(FP), R0
+	LDP  0(R0), (R8, R9)
+	LDP  16(R0), (R10, R11)
+	LDP  32(R0), (R12, R13)
+	MOVD ZR, R2
+	MOVD ZR, R3
+	MOVD ZR, R4
+	MOVD ZR, R5
+	MOVD ZR, R6
+	MOVD ZR, R7
+	MOVD ZR, R14
+	MOVD ZR, R15
+	MOVD ZR, R16
+	MOVD ZR, R17
+	MOVD ZR, R19
+	MOVD ZR, R20
+
+	// the products x[i]x[j], i < j, are computed once and doubled before the
+	// squares x[i]² are added.
+	MOVD ZR, R25
+	fqMulAdd(R8, R9, R3)
+	fqMulAdd(R8, R10, R4)
+	fqMulAdd(R8, R11, R5)
+	fqMulAdd(R8, R12, R6)
+	fqMulAdd(R8, R13, R7)
+	MOVD R25, R14
+
+	MOVD ZR, R25
+	fqMulAdd(R9, R10, R5)
+	fqMulAdd(R9, R11, R6)
+	fqMulAdd(R9, R12, R7)
+	fqMulAdd(R9, R13, R14)
+	MOVD R25, R15
+
+	MOVD ZR, R25
+	fqMulAdd(R10, R11, R7)
+	fqMulAdd(R10, R12, R14)
+	fqMulAdd(R10, R13, R15)
+	MOVD R25, R16
+
+	MOVD ZR, R25
+	fqMulAdd(R11, R12, R15)
+	fqMulAdd(R11, R13, R16)
+	MOVD R25, R17
+
+	MOVD ZR, R25
+	fqMulAdd(R12, R13, R17)
+	MOVD R25, R19
+
+	ADDS R3, R3, R3
+	ADCS R4, R4, R4
+	ADCS R5, R5, R5
+	ADCS R6, R6, R6
+	ADCS R7, R7, R7
+	ADCS R14, R14, R14
+	ADCS R15, R15, R15
+	ADCS R16, R16, R16
+	ADCS R17, R17, R17
+	ADCS R19, R19, R19
+	ADC  R20, R20, R20
+
+	MUL   R8, R8, R23
+	UMULH R8, R8, R24
+	ADDS R23, R2, R2
+	ADCS R24, R3, R3
+	MUL   R9, R9, R23
+	UMULH R9, R9, R24
+	ADCS R23, R4, R4
+	ADCS R24, R5, R5
+	MUL   R10, R10, R23
+	UMULH R10, R10, R24
+	ADCS R23, R6, R6
+	ADCS R24, R7, R7
+	MUL   R11, R11, R23
+	UMULH R11, R11, R24
+	ADCS R23, R14, R14
+	ADCS R24, R15, R15
+	MUL   R12, R12, R23
+	UMULH R12, R12, R24
+	ADCS R23, R16, R16
+	ADCS R24, R17, R17
+	MUL   R13, R13, R23
+	UMULH R13, R13, R24
+	ADCS R23, R19, R19
+	ADCS R24, R20, R20
+
+	fqLoadQ
+	MOVD ZR, R21
+	MOVD $0x89f3fffcfffcfffd, R22
+	fqREDCRound(R2, R3, R4, R5, R6, R7, R14)
+	fqREDCRound(R3, R4, R5, R6, R7, R14, R15)
+	fqREDCRound(R4, R5, R6, R7, R14, R15, R16)
+	fqREDCRound(R5, R6, R7, R14, R15, R16, R17)
+	fqREDCRound(R6, R7, R14, R15, R16, R17, R19)
+	fqREDCRound(R7, R14, R15, R16, R17, R19, R20)
+
+	fqMod(R14, R15, R16, R17, R19, R20, R2, R3, R4, R5, R6, R7)
+	fqStore(R14, R15, R16, R17, R19, R20)
+	RET
@@ -1,4 +1,4 @@
-// +build amd64,!generic arm64,!generic
+// +build amd64,!generic arm64,arm64asm,!generic
 
 package bls12
 
@@ -9,13 +9,21 @@ import (
 var hasBMI2 = cpu.X86.HasBMI2
 
 // fqAdd sets z to the sum x+y.
+//go:noescape
 func fqAdd(z, x, y *fq)
 
 // fqNeg sets z to -x.
+//go:noescape
 func fqNeg(z, x *fq)
 
 // fqSub sets z to the difference x-y.
+//go:noescape
 func fqSub(z, x, y *fq)
 
 // fqMul sets z to the product x*y.
+//go:noescape
 func fqMul(z, x, y *fq)
+
+// fqSqr sets z to x².
+//go:noescape
+func fqSqr(z, x *fq)
@@ -0,0 +1,16 @@
+// +build amd64,!generic arm64,arm64asm,!generic
+
+package bls12
+
+import "runtime"
+
+// forEachBackend calls f with the assembly routines of the build, once per
+// code path.
+func forEachBackend(f func(backend string)) {
+	f("asm")
+	if runtime.GOARCH == "amd64" && hasBMI2 {
+		hasBMI2 = false
+		defer func() { hasBMI2 = true }()
+		f("asm without BMI2")
+	}
+}
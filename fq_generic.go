@@ -1,7 +1,9 @@
-// +build !amd64,!arm64 generic
+// +build !amd64,!arm64 !amd64,!arm64asm generic
 
 package bls12
 
+import "math/bits"
+
 const (
 	halfWordSize = wordSize / 2
 	halfWordMask = (1 << halfWordSize) - 1
@@ -58,24 +60,23 @@ func fqBasicMul(z *fqLarge, x, y *fq) {
 	var carry uint64
 	for i, yi := range y {
 		carry = 0
-		if yi != 0 {
-			y0, y1 := yi&halfWordMask, yi>>halfWordSize
-			for j, xj := range x {
-				x0, x1 := xj&halfWordMask, xj>>halfWordSize
-
-				// See Hacker's Delight - Multiword Multiplication
-				x0y0 := x0 * y0
-				x1y0 := x1 * y0
-				x0y1 := x0 * y1
-				x1y1 := x1 * y1
-				w0 := (x0y0 & halfWordMask) + (z[i+j] & halfWordMask) + (carry & halfWordMask)
-				w1 := (w0 >> halfWordSize) + (x0y0 >> halfWordSize) + (z[i+j] >> halfWordSize) + (x1y0 & halfWordMask) + (x0y1 & halfWordMask) + (carry >> halfWordSize)
-				w2 := (w1 >> halfWordSize) + (x1y0 >> halfWordSize) + (x0y1 >> halfWordSize) + (x1y1 & halfWordMask)
-				carry = (((w2 >> halfWordSize) + (x1y1 >> halfWordSize)) << halfWordSize) | (w2 & halfWordMask)
-				z[i+j] = (w1 << halfWordSize) | (w0 & halfWordMask)
-			}
-			z[i+fqLen] = carry
+		// the loop runs even if yi is 0 so that the timing does not depend on y.
+		y0, y1 := yi&halfWordMask, yi>>halfWordSize
+		for j, xj := range x {
+			x0, x1 := xj&halfWordMask, xj>>halfWordSize
+
+			// See Hacker's Delight - Multiword Multiplication
+			x0y0 := x0 * y0
+			x1y0 := x1 * y0
+			x0y1 := x0 * y1
+			x1y1 := x1 * y1
+			w0 := (x0y0 & halfWordMask) + (z[i+j] & halfWordMask) + (carry & halfWordMask)
+			w1 := (w0 >> halfWordSize) + (x0y0 >> halfWordSize) + (z[i+j] >> halfWordSize) + (x1y0 & halfWordMask) + (x0y1 & halfWordMask) + (carry >> halfWordSize)
+			w2 := (w1 >> halfWordSize) + (x1y0 >> halfWordSize) + (x0y1 >> halfWordSize) + (x1y1 & halfWordMask)
+			carry = (((w2 >> halfWordSize) + (x1y1 >> halfWordSize)) << halfWordSize) | (w2 & halfWordMask)
+			z[i+j] = (w1 << halfWordSize) | (w0 & halfWordMask)
 		}
+		z[i+fqLen] = carry
 	}
 }
 
@@ -89,25 +90,23 @@ func fqREDC(c *fq, x *fqLarge) {
 		// 2. k=(r(r^−1 mod n)−1)/n
 		// 5. s=(x*k mod r);
 		s := x[i] * qK64
-		if s != 0 {
-			s0, s1 := s&halfWordMask, s>>halfWordSize
-			for j, q := range q64 {
-				q0, q1 := q&halfWordMask, q>>halfWordSize
-
-				// See Hacker's Delight - Multiword Multiplication
-				q0s0 := q0 * s0
-				q1s0 := q1 * s0
-				q0s1 := q0 * s1
-				q1s1 := q1 * s1
-				w0 := (q0s0 & halfWordMask) + (x[i+j] & halfWordMask) + (carryMul & halfWordMask)
-				w1 := (w0 >> halfWordSize) + (q0s0 >> halfWordSize) + (x[i+j] >> halfWordSize) + (q1s0 & halfWordMask) + (q0s1 & halfWordMask) + (carryMul >> halfWordSize)
-				w2 := (w1 >> halfWordSize) + (q1s0 >> halfWordSize) + (q0s1 >> halfWordSize) + (q1s1 & halfWordMask)
-				carryMul = (((w2 >> halfWordSize) + (q1s1 >> halfWordSize)) << halfWordSize) | (w2 & halfWordMask)
-				if j > 0 {
-					// note(rgeraldes): since the low order bits are going to be discarded and
-					// x[i+j=0] is not used anymore during the program, we can skip the assignment.
-					x[i+j] = (w1 << halfWordSize) | (w0 & halfWordMask)
-				}
+		s0, s1 := s&halfWordMask, s>>halfWordSize
+		for j, q := range q64 {
+			q0, q1 := q&halfWordMask, q>>halfWordSize
+
+			// See Hacker's Delight - Multiword Multiplication
+			q0s0 := q0 * s0
+			q1s0 := q1 * s0
+			q0s1 := q0 * s1
+			q1s1 := q1 * s1
+			w0 := (q0s0 & halfWordMask) + (x[i+j] & halfWordMask) + (carryMul & halfWordMask)
+			w1 := (w0 >> halfWordSize) + (q0s0 >> halfWordSize) + (x[i+j] >> halfWordSize) + (q1s0 & halfWordMask) + (q0s1 & halfWordMask) + (carryMul >> halfWordSize)
+			w2 := (w1 >> halfWordSize) + (q1s0 >> halfWordSize) + (q0s1 >> halfWordSize) + (q1s1 & halfWordMask)
+			carryMul = (((w2 >> halfWordSize) + (q1s1 >> halfWordSize)) << halfWordSize) | (w2 & halfWordMask)
+			if j > 0 {
+				// note(rgeraldes): since the low order bits are going to be discarded and
+				// x[i+j=0] is not used anymore during the program, we can skip the assignment.
+				x[i+j] = (w1 << halfWordSize) | (w0 & halfWordMask)
 			}
 		}
 
@@ -133,3 +132,41 @@ func fqMul(z, x, y *fq) {
 	fqBasicMul(large, x, y)
 	fqREDC(z, large)
 }
+
+// fqBasicSqr sets z, which must be 0, to x² with the symmetric product: the
+// products x[i]x[j], i < j, are computed once and doubled before the squares
+// x[i]² are added.
+func fqBasicSqr(z *fqLarge, x *fq) {
+	for i := 0; i < fqLen-1; i++ {
+		var carry uint64
+		for j := i + 1; j < fqLen; j++ {
+			hi, lo := bits.Mul64(x[i], x[j])
+			var c uint64
+			lo, c = bits.Add64(lo, z[i+j], 0)
+			hi += c
+			z[i+j], c = bits.Add64(lo, carry, 0)
+			carry = hi + c
+		}
+		z[i+fqLen] = carry
+	}
+
+	z[2*fqLen-1] = z[2*fqLen-2] >> (wordSize - 1)
+	for i := 2*fqLen - 2; i > 1; i-- {
+		z[i] = z[i]<<1 | z[i-1]>>(wordSize-1)
+	}
+	z[1] <<= 1
+
+	var carry uint64
+	for i, xi := range x {
+		hi, lo := bits.Mul64(xi, xi)
+		z[2*i], carry = bits.Add64(z[2*i], lo, carry)
+		z[2*i+1], carry = bits.Add64(z[2*i+1], hi, carry)
+	}
+}
+
+// fqSqr sets z to x², with fqBasicSqr followed by the montgomery reduction.
+func fqSqr(z, x *fq) {
+	large := new(fqLarge)
+	fqBasicSqr(large, x)
+	fqREDC(z, large)
+}
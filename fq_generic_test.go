@@ -0,0 +1,8 @@
+// +build !amd64,!arm64 !amd64,!arm64asm generic
+
+package bls12
+
+// forEachBackend calls f with the generic routines.
+func forEachBackend(f func(backend string)) {
+	f("generic")
+}
@@ -1,6 +1,8 @@
 package bls12
 
 import (
+	"bytes"
+	"encoding/hex"
 	"math/big"
 	"testing"
 )
@@ -284,6 +286,57 @@ func TestFqMul(t *testing.T) {
 	}
 }
 
+func TestFqSqr(t *testing.T) {
+	tests := map[string]struct {
+		x, want fq
+	}{
+		"mont(1)² = mont(1)": {
+			x:    fq{0x760900000002fffd, 0xebf4000bc40c0002, 0x5f48985753c758ba, 0x77ce585370525745, 0x5c071a97a256ec6d, 0x15f65ec3fa80e493},
+			want: fq{0x760900000002fffd, 0xebf4000bc40c0002, 0x5f48985753c758ba, 0x77ce585370525745, 0x5c071a97a256ec6d, 0x15f65ec3fa80e493},
+		},
+		"mont(last)² = mont(1)": {
+			x:    fq{0x43F5FFFFFFFCAAAE, 0x32B7FFF2ED47FFFD, 0x7E83A49A2E99D69, 0xECA8F3318332BB7A, 0xEF148D1EA0F4C069, 0x40AB3263EFF0206},
+			want: fq{0x760900000002fffd, 0xebf4000bc40c0002, 0x5f48985753c758ba, 0x77ce585370525745, 0x5c071a97a256ec6d, 0x15f65ec3fa80e493},
+		},
+		"0² = 0": {},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			forEachBackend(func(backend string) {
+				var got fq
+				fqSqr(&got, &tc.x)
+				if got != tc.want {
+					t.Fatalf("%s: expected: %v, got: %v", backend, tc.want, got)
+				}
+			})
+		})
+	}
+}
+
+// FuzzFqSqr checks fqSqr against math/big, for all the code paths of the
+// build.
+func FuzzFqSqr(f *testing.F) {
+	qMinusOne := new(big.Int).Sub(q, big.NewInt(1))
+	for _, seed := range [][]byte{{}, {1}, qMinusOne.Bytes(), bytes.Repeat([]byte{0xff}, fqByteLen)} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		bigX := new(big.Int).SetBytes(b)
+		bigX.Mod(bigX, q)
+		x, _ := new(fq).SetInt(bigX)
+		want := new(big.Int).Mul(bigX, bigX)
+		want.Mod(want, q)
+		forEachBackend(func(backend string) {
+			var z fq
+			fqSqr(&z, x)
+			if got := z.Int(); got.Cmp(want) != 0 {
+				t.Fatalf("%s: %x: expected: %x, got: %x", backend, bigX, want, got)
+			}
+		})
+	})
+}
+
 func TestFqExp(t *testing.T) {
 	tests := map[string]struct {
 		x    fq
@@ -336,3 +389,120 @@ func TestFqInv(t *testing.T) {
 		})
 	}
 }
+
+func TestFqCMov(t *testing.T) {
+	tests := map[string]struct {
+		x, y fq
+		b    uint64
+		want fq
+	}{
+		"b = 0 > x": {x: fq{1, 2, 3, 4, 5, 6}, y: fq{6, 5, 4, 3, 2, 1}, b: 0, want: fq{1, 2, 3, 4, 5, 6}},
+		"b = 1 > y": {x: fq{1, 2, 3, 4, 5, 6}, y: fq{6, 5, 4, 3, 2, 1}, b: 1, want: fq{6, 5, 4, 3, 2, 1}},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got fq
+			fqCMov(&got, &tc.x, &tc.y, tc.b)
+			if got != tc.want {
+				t.Fatalf("expected: %v, got: %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestFqEqual(t *testing.T) {
+	tests := map[string]struct {
+		x, y fq
+		want uint64
+	}{
+		"0 = 0":          {x: fq{}, y: fq{}, want: 1},
+		"x = x":          {x: fq{1, 2, 3, 4, 5, 6}, y: fq{1, 2, 3, 4, 5, 6}, want: 1},
+		"x != y (low)":   {x: fq{1, 2, 3, 4, 5, 6}, y: fq{0, 2, 3, 4, 5, 6}, want: 0},
+		"x != y (high)":  {x: fq{1, 2, 3, 4, 5, 6}, y: fq{1, 2, 3, 4, 5, 7}, want: 0},
+		"0 != last word": {x: fq{}, y: fq{0, 0, 0, 0, 0, 1 << 63}, want: 0},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := fqEqual(&tc.x, &tc.y)
+			if got != tc.want {
+				t.Fatalf("expected: %v, got: %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestFqSgn0(t *testing.T) {
+	tests := map[string]struct {
+		input fq
+		want  uint64
+	}{
+		"sgn0(mont(0)) = 0":    {input: fq{}, want: 0},
+		"sgn0(mont(1)) = 1":    {input: fq{0x760900000002fffd, 0xebf4000bc40c0002, 0x5f48985753c758ba, 0x77ce585370525745, 0x5c071a97a256ec6d, 0x15f65ec3fa80e493}, want: 1},
+		"sgn0(mont(last)) = 0": {input: fq{0x43F5FFFFFFFCAAAE, 0x32B7FFF2ED47FFFD, 0x7E83A49A2E99D69, 0xECA8F3318332BB7A, 0xEF148D1EA0F4C069, 0x40AB3263EFF0206}, want: 0},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := fqSgn0(&tc.input)
+			if got != tc.want {
+				t.Fatalf("expected: %v, got: %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestFqIsSquare(t *testing.T) {
+	tests := map[string]struct {
+		input string
+	}{
+		"0":   {input: "0"},
+		"1":   {input: "1"},
+		"2":   {input: "2"},
+		"4":   {input: "4"},
+		"11":  {input: "11"},
+		"-1":  {input: "4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559786"},
+		"-3":  {input: "4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559784"},
+		"A´":  {input: "12190336318893619529228877361869031420615612348429846051986726275283378313155663745811710833465465981901188123677"},
+		"B´":  {input: "2906670324641927570491258158026293881577086121416628140204402091718288198173574630967936031029026176254968826637280"},
+		"big": {input: "590728492726997966099618626120482682095437733689734941576187760067601492637096712773899690116980580577616657575925"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			x, _ := new(fq).SetString(tc.input)
+			var want uint64
+			if big.Jacobi(x.Int(), q) >= 0 {
+				want = 1
+			}
+			got := fqIsSquare(x)
+			if got != want {
+				t.Fatalf("expected: %v, got: %v", want, got)
+			}
+		})
+	}
+}
+
+func TestFqSetBytes(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		want  *big.Int
+		err   error
+	}{
+		"0":     {input: "000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000", want: big.NewInt(0)},
+		"1":     {input: "000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000001", want: big.NewInt(1)},
+		"q - 1": {input: "1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaaa", want: new(big.Int).Sub(q, big.NewInt(1))},
+		"q":     {input: "1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab", err: errOutOfBounds},
+		"max":   {input: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", err: errOutOfBounds},
+		"short": {input: "01", err: errOutOfBounds},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			b, _ := hex.DecodeString(tc.input)
+			got, err := new(fq).SetBytes(b)
+			if err != tc.err {
+				t.Fatalf("expected: %v, got: %v", tc.err, err)
+			}
+			if err == nil && got.Int().Cmp(tc.want) != 0 {
+				t.Fatalf("expected: %v, got: %v", tc.want, got.Int())
+			}
+		})
+	}
+}
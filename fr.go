@@ -0,0 +1,282 @@
+package bls12
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"math/bits"
+)
+
+const (
+	frLen     = scalarWords
+	frByteLen = 32
+
+	// frTwoAdicity is the largest s such that 2^s divides r-1.
+	frTwoAdicity = 32
+)
+
+// ErrInvalidFrLength is returned when decoding a scalar of the wrong length.
+var ErrInvalidFrLength = errors.New("bls12: invalid scalar encoding length")
+
+// Fr is an element of the scalar field, the finite field of order r.
+// Fr operates, internally, on the montgomery form, like fq. The zero value is
+// the element 0; the other elements must be obtained through the setters so
+// that the montgomery form is used. Fr runs in constant time, except for
+// SetInt, which leaks the length of the big.Int, and Exp, which is variable
+// time in the exponent. Secret scalars should be set with SetBytes or
+// SetCanonicalBytes.
+type Fr [frLen]uint64
+
+// RandFr returns a random non-zero scalar read from reader.
+func RandFr(reader io.Reader) (*Fr, error) {
+	// The 512 bit values reduced modulo r are uniform up to a bias of 2^-256.
+	var b [2 * frByteLen]byte
+	for {
+		if _, err := io.ReadFull(reader, b[:]); err != nil {
+			return nil, err
+		}
+		if z := new(Fr).SetBytes(b[:]); !z.IsZero() {
+			return z, nil
+		}
+	}
+}
+
+// Set sets z to x and returns z.
+func (z *Fr) Set(x *Fr) *Fr {
+	*z = *x
+	return z
+}
+
+// SetZero sets z to 0 and returns z.
+func (z *Fr) SetZero() *Fr {
+	*z = Fr{}
+	return z
+}
+
+// SetOne sets z to 1 and returns z.
+func (z *Fr) SetOne() *Fr {
+	*z = *frOne
+	return z
+}
+
+// SetUint64 sets z to x and returns z.
+func (z *Fr) SetUint64(x uint64) *Fr {
+	frMul(z, &Fr{x}, frR2)
+	return z
+}
+
+// SetInt sets z to x mod r and returns z. The reduction of x is not constant
+// time and leaks the length of x.
+func (z *Fr) SetInt(x *big.Int) *Fr {
+	k := Fr(ctScalar(x))
+	frMul(z, &k, frR2)
+	return z
+}
+
+// Int returns the corresponding big integer.
+func (x *Fr) Int() *big.Int {
+	k := x.words()
+	return wordsToInt(k[:])
+}
+
+// SetBytes sets z to the value of the big-endian byte slice b reduced modulo r
+// and returns z. b can have any length, and SetBytes runs in constant time with
+// respect to its contents.
+func (z *Fr) SetBytes(b []byte) *Fr {
+	// z is accumulated from the most significant 64 bit word: z = z 2^64 + w.
+	ret, w := new(Fr), new(Fr)
+	for len(b) > 0 {
+		n := (len(b)-1)%8 + 1
+		var word uint64
+		for _, bi := range b[:n] {
+			word = word<<8 | uint64(bi)
+		}
+		b = b[n:]
+
+		frMul(ret, ret, frWord)
+		frMul(w, &Fr{word}, frR2)
+		frAdd(ret, ret, w)
+	}
+
+	return z.Set(ret)
+}
+
+// SetCanonicalBytes sets z to the value of the 32-byte big-endian slice b and
+// returns z. The value must be smaller than r.
+func (z *Fr) SetCanonicalBytes(b []byte) (*Fr, error) {
+	if len(b) != frByteLen {
+		return nil, ErrInvalidFrLength
+	}
+
+	var k Fr
+	for i := range k {
+		k[i] = binary.BigEndian.Uint64(b[frByteLen-(i+1)*8:])
+	}
+	var borrow uint64
+	for i := range k {
+		_, borrow = bits.Sub64(k[i], r64[i], borrow)
+	}
+	if borrow == 0 {
+		return nil, ErrNonCanonical
+	}
+
+	frMul(z, &k, frR2)
+	return z, nil
+}
+
+// Bytes returns the canonical 32-byte big-endian encoding of x.
+func (x *Fr) Bytes() []byte {
+	k := x.words()
+	ret := make([]byte, frByteLen)
+	for i, ki := range k {
+		binary.BigEndian.PutUint64(ret[frByteLen-(i+1)*8:], ki)
+	}
+	return ret
+}
+
+// String implements the Stringer interface.
+func (x *Fr) String() string {
+	k := x.words()
+	return fmt.Sprintf("%16.16x%16.16x%16.16x%16.16x", k[3], k[2], k[1], k[0])
+}
+
+// words returns x in the standard form as little-endian 64 bit words.
+func (x *Fr) words() [scalarWords]uint64 {
+	var k Fr
+	frMul(&k, x, &Fr{1})
+	return [scalarWords]uint64(k)
+}
+
+// Equal reports whether x is equal to y.
+func (x *Fr) Equal(y *Fr) bool {
+	return frEqual(x, y) == 1
+}
+
+// IsZero reports whether x is equal to 0.
+func (x *Fr) IsZero() bool {
+	return frEqual(x, &Fr{}) == 1
+}
+
+// Add sets z to the sum x+y and returns z.
+func (z *Fr) Add(x, y *Fr) *Fr {
+	frAdd(z, x, y)
+	return z
+}
+
+// Sub sets z to the difference x-y and returns z.
+func (z *Fr) Sub(x, y *Fr) *Fr {
+	frSub(z, x, y)
+	return z
+}
+
+// Neg sets z to -x and returns z.
+func (z *Fr) Neg(x *Fr) *Fr {
+	frNeg(z, x)
+	return z
+}
+
+// Mul sets z to the product x*y and returns z.
+func (z *Fr) Mul(x, y *Fr) *Fr {
+	frMul(z, x, y)
+	return z
+}
+
+// Square sets z to x² and returns z.
+func (z *Fr) Square(x *Fr) *Fr {
+	frMul(z, x, x)
+	return z
+}
+
+// Inv sets z to 1/x and returns z. The inverse of 0 is 0.
+func (z *Fr) Inv(x *Fr) *Fr {
+	frExp(z, x, rMinusTwo)
+	return z
+}
+
+// Exp sets z to x^y and returns z. If y is negative, Exp returns (1/x)^|y|.
+// Exp is not constant time with respect to y.
+func (z *Fr) Exp(x *Fr, y *big.Int) *Fr {
+	b := *x
+	if y.Sign() < 0 {
+		b.Inv(&b)
+		y = new(big.Int).Neg(y)
+	}
+
+	ret := *frOne
+	for i := y.BitLen() - 1; i >= 0; i-- {
+		frMul(&ret, &ret, &ret)
+		if y.Bit(i) == 1 {
+			frMul(&ret, &ret, &b)
+		}
+	}
+
+	return z.Set(&ret)
+}
+
+// Sqrt sets z to a square root of x and returns z. If x is not a square,
+// Sqrt leaves z unchanged and returns nil. Sqrt runs in constant time.
+// See https://www.rfc-editor.org/rfc/rfc9380.html#appendix-I.4.
+func (z *Fr) Sqrt(x *Fr) *Fr {
+	// r-1 = 2^frTwoAdicity t; ret = x^((t+1)/2) is corrected by powers of the
+	// root of unity c until ret² = x, with b = x^t tracking the ratio ret²/x.
+	ret, b, c := new(Fr), new(Fr), new(Fr).Set(frRootOfUnity)
+	frExp(ret, x, rMinusOneOddOverTwo)
+	frMul(b, ret, ret)
+	frMul(b, b, x)
+	frMul(ret, ret, x)
+
+	t, tb, tc := new(Fr).Set(b), new(Fr), new(Fr)
+	for i := frTwoAdicity; i >= 2; i-- {
+		for j := 1; j <= i-2; j++ {
+			frMul(t, t, t)
+		}
+		e := 1 ^ frEqual(t, frOne)
+		frMul(tc, ret, c)
+		frCMov(ret, ret, tc, e)
+		frMul(c, c, c)
+		frMul(tb, b, c)
+		frCMov(b, b, tb, e)
+		t.Set(b)
+	}
+
+	frMul(tb, ret, ret)
+	if frEqual(tb, x) == 0 {
+		return nil
+	}
+	return z.Set(ret)
+}
+
+// frExp sets z to x^y, where y is given as little-endian 64 bit words.
+func frExp(z, x *Fr, y []uint64) {
+	b := *x
+	ret := *frOne
+	for _, word := range y {
+		for j := uint(0); j < wordSize; j++ {
+			if (word & (1 << j)) != 0 {
+				frMul(&ret, &ret, &b)
+			}
+			frMul(&b, &b, &b)
+		}
+	}
+
+	z.Set(&ret)
+}
+
+// frCMov sets z to y if b is 1 and to x if b is 0, in constant time.
+func frCMov(z, x, y *Fr, b uint64) {
+	mask := -b
+	for i := range z {
+		z[i] = x[i] ^ (mask & (x[i] ^ y[i]))
+	}
+}
+
+// frEqual returns 1 if x is equal to y and 0 otherwise, in constant time.
+func frEqual(x, y *Fr) uint64 {
+	var acc uint64
+	for i := range x {
+		acc |= x[i] ^ y[i]
+	}
+	return 1 ^ ((acc | -acc) >> (wordSize - 1))
+}
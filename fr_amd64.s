@@ -0,0 +1,572 @@
+// Code generated by command: go run asm.go -field fr -out ../fr_amd64.s. DO NOT EDIT.
+
+// +build amd64,!generic
+
+// func frAdd(z *[4]uint64, x *[4]uint64, y *[4]uint64)
+TEXT ·frAdd(SB), $0-24
+	MOVQ    x+8(FP), SI
+	MOVQ    y+16(FP), DI
+	MOVQ    (SI), R8
+	MOVQ    8(SI), R9
+	MOVQ    16(SI), R10
+	MOVQ    24(SI), R11
+	ADDQ    (DI), R8
+	ADCQ    8(DI), R9
+	ADCQ    16(DI), R10
+	ADCQ    24(DI), R11
+	MOVQ    R8, R14
+	MOVQ    R9, R15
+	MOVQ    R10, BX
+	MOVQ    R11, CX
+	SUBQ    ·r64+0(SB), R14
+	SBBQ    ·r64+8(SB), R15
+	SBBQ    ·r64+16(SB), BX
+	SBBQ    ·r64+24(SB), CX
+	CMOVQCC R14, R8
+	CMOVQCC R15, R9
+	CMOVQCC BX, R10
+	CMOVQCC CX, R11
+	MOVQ    z+0(FP), SI
+	MOVQ    R8, (SI)
+	MOVQ    R9, 8(SI)
+	MOVQ    R10, 16(SI)
+	MOVQ    R11, 24(SI)
+	RET
+
+// func frNeg(z *[4]uint64, x *[4]uint64)
+TEXT ·frNeg(SB), $0-16
+	MOVQ    x+8(FP), SI
+	MOVQ    ·r64+0(SB), R8
+	MOVQ    ·r64+8(SB), R9
+	MOVQ    ·r64+16(SB), R10
+	MOVQ    ·r64+24(SB), R11
+	SUBQ    (SI), R8
+	SBBQ    8(SI), R9
+	SBBQ    16(SI), R10
+	SBBQ    24(SI), R11
+	MOVQ    R8, R14
+	MOVQ    R9, R15
+	MOVQ    R10, BX
+	MOVQ    R11, CX
+	SUBQ    ·r64+0(SB), R14
+	SBBQ    ·r64+8(SB), R15
+	SBBQ    ·r64+16(SB), BX
+	SBBQ    ·r64+24(SB), CX
+	CMOVQCC R14, R8
+	CMOVQCC R15, R9
+	CMOVQCC BX, R10
+	CMOVQCC CX, R11
+	MOVQ    z+0(FP), SI
+	MOVQ    R8, (SI)
+	MOVQ    R9, 8(SI)
+	MOVQ    R10, 16(SI)
+	MOVQ    R11, 24(SI)
+	RET
+
+// func frSub(z *[4]uint64, x *[4]uint64, y *[4]uint64)
+TEXT ·frSub(SB), $0-24
+	MOVQ    x+8(FP), SI
+	MOVQ    y+16(FP), DI
+	MOVQ    (SI), R8
+	MOVQ    8(SI), R9
+	MOVQ    16(SI), R10
+	MOVQ    24(SI), R11
+	SUBQ    (DI), R8
+	SBBQ    8(DI), R9
+	SBBQ    16(DI), R10
+	SBBQ    24(DI), R11
+	MOVQ    ·r64+0(SB), R14
+	MOVQ    ·r64+8(SB), R15
+	MOVQ    ·r64+16(SB), BX
+	MOVQ    ·r64+24(SB), CX
+	MOVQ    $0x00000000, AX
+	CMOVQCC AX, R14
+	CMOVQCC AX, R15
+	CMOVQCC AX, BX
+	CMOVQCC AX, CX
+	ADDQ    R14, R8
+	ADCQ    R15, R9
+	ADCQ    BX, R10
+	ADCQ    CX, R11
+	MOVQ    z+0(FP), SI
+	MOVQ    R8, (SI)
+	MOVQ    R9, 8(SI)
+	MOVQ    R10, 16(SI)
+	MOVQ    R11, 24(SI)
+	RET
+
+// func frMul(z *[4]uint64, x *[4]uint64, y *[4]uint64)
+TEXT ·frMul(SB), $0-24
+	MOVQ  x+8(FP), SI
+	MOVQ  y+16(FP), DI
+	XORQ  R8, R8
+	XORQ  R9, R9
+	XORQ  R10, R10
+	XORQ  R11, R11
+	XORQ  R12, R12
+	XORQ  R13, R13
+	CMPB  ·hasBMI2+0(SB), $0x00
+	JE    fallback
+	MOVQ  (DI), DX
+	MULXQ (SI), AX, BX
+	ADDQ  AX, R8
+	ADCQ  $0x00, BX
+	MOVQ  BX, R14
+	MULXQ 8(SI), AX, BX
+	ADDQ  AX, R9
+	ADCQ  $0x00, BX
+	ADDQ  R14, R9
+	ADCQ  $0x00, BX
+	MOVQ  BX, R14
+	MULXQ 16(SI), AX, BX
+	ADDQ  AX, R10
+	ADCQ  $0x00, BX
+	ADDQ  R14, R10
+	ADCQ  $0x00, BX
+	MOVQ  BX, R14
+	MULXQ 24(SI), AX, BX
+	ADDQ  AX, R11
+	ADCQ  $0x00, BX
+	ADDQ  R14, R11
+	ADCQ  $0x00, BX
+	MOVQ  BX, R14
+	ADDQ  R14, R12
+	ADCQ  $0x00, R13
+	MOVQ  $0xfffffffeffffffff, R15
+	IMULQ R8, R15
+	MOVQ  R15, DX
+	MULXQ ·r64+0(SB), AX, BX
+	ADDQ  AX, R8
+	ADCQ  $0x00, BX
+	MOVQ  BX, R14
+	MULXQ ·r64+8(SB), AX, BX
+	ADDQ  AX, R9
+	ADCQ  $0x00, BX
+	ADDQ  R14, R9
+	ADCQ  $0x00, BX
+	MOVQ  BX, R14
+	MULXQ ·r64+16(SB), AX, BX
+	ADDQ  AX, R10
+	ADCQ  $0x00, BX
+	ADDQ  R14, R10
+	ADCQ  $0x00, BX
+	MOVQ  BX, R14
+	MULXQ ·r64+24(SB), AX, BX
+	ADDQ  AX, R11
+	ADCQ  $0x00, BX
+	ADDQ  R14, R11
+	ADCQ  $0x00, BX
+	MOVQ  BX, R14
+	ADDQ  R14, R12
+	ADCQ  $0x00, R13
+	MOVQ  8(DI), DX
+	MULXQ (SI), AX, BX
+	ADDQ  AX, R9
+	ADCQ  $0x00, BX
+	MOVQ  BX, R14
+	MULXQ 8(SI), AX, BX
+	ADDQ  AX, R10
+	ADCQ  $0x00, BX
+	ADDQ  R14, R10
+	ADCQ  $0x00, BX
+	MOVQ  BX, R14
+	MULXQ 16(SI), AX, BX
+	ADDQ  AX, R11
+	ADCQ  $0x00, BX
+	ADDQ  R14, R11
+	ADCQ  $0x00, BX
+	MOVQ  BX, R14
+	MULXQ 24(SI), AX, BX
+	ADDQ  AX, R12
+	ADCQ  $0x00, BX
+	ADDQ  R14, R12
+	ADCQ  $0x00, BX
+	MOVQ  BX, R14
+	ADDQ  R14, R13
+	ADCQ  $0x00, R8
+	MOVQ  $0xfffffffeffffffff, R15
+	IMULQ R9, R15
+	MOVQ  R15, DX
+	MULXQ ·r64+0(SB), AX, BX
+	ADDQ  AX, R9
+	ADCQ  $0x00, BX
+	MOVQ  BX, R14
+	MULXQ ·r64+8(SB), AX, BX
+	ADDQ  AX, R10
+	ADCQ  $0x00, BX
+	ADDQ  R14, R10
+	ADCQ  $0x00, BX
+	MOVQ  BX, R14
+	MULXQ ·r64+16(SB), AX, BX
+	ADDQ  AX, R11
+	ADCQ  $0x00, BX
+	ADDQ  R14, R11
+	ADCQ  $0x00, BX
+	MOVQ  BX, R14
+	MULXQ ·r64+24(SB), AX, BX
+	ADDQ  AX, R12
+	ADCQ  $0x00, BX
+	ADDQ  R14, R12
+	ADCQ  $0x00, BX
+	MOVQ  BX, R14
+	ADDQ  R14, R13
+	ADCQ  $0x00, R8
+	MOVQ  16(DI), DX
+	MULXQ (SI), AX, BX
+	ADDQ  AX, R10
+	ADCQ  $0x00, BX
+	MOVQ  BX, R14
+	MULXQ 8(SI), AX, BX
+	ADDQ  AX, R11
+	ADCQ  $0x00, BX
+	ADDQ  R14, R11
+	ADCQ  $0x00, BX
+	MOVQ  BX, R14
+	MULXQ 16(SI), AX, BX
+	ADDQ  AX, R12
+	ADCQ  $0x00, BX
+	ADDQ  R14, R12
+	ADCQ  $0x00, BX
+	MOVQ  BX, R14
+	MULXQ 24(SI), AX, BX
+	ADDQ  AX, R13
+	ADCQ  $0x00, BX
+	ADDQ  R14, R13
+	ADCQ  $0x00, BX
+	MOVQ  BX, R14
+	ADDQ  R14, R8
+	ADCQ  $0x00, R9
+	MOVQ  $0xfffffffeffffffff, R15
+	IMULQ R10, R15
+	MOVQ  R15, DX
+	MULXQ ·r64+0(SB), AX, BX
+	ADDQ  AX, R10
+	ADCQ  $0x00, BX
+	MOVQ  BX, R14
+	MULXQ ·r64+8(SB), AX, BX
+	ADDQ  AX, R11
+	ADCQ  $0x00, BX
+	ADDQ  R14, R11
+	ADCQ  $0x00, BX
+	MOVQ  BX, R14
+	MULXQ ·r64+16(SB), AX, BX
+	ADDQ  AX, R12
+	ADCQ  $0x00, BX
+	ADDQ  R14, R12
+	ADCQ  $0x00, BX
+	MOVQ  BX, R14
+	MULXQ ·r64+24(SB), AX, BX
+	ADDQ  AX, R13
+	ADCQ  $0x00, BX
+	ADDQ  R14, R13
+	ADCQ  $0x00, BX
+	MOVQ  BX, R14
+	ADDQ  R14, R8
+	ADCQ  $0x00, R9
+	MOVQ  24(DI), DX
+	MULXQ (SI), AX, BX
+	ADDQ  AX, R11
+	ADCQ  $0x00, BX
+	MOVQ  BX, R14
+	MULXQ 8(SI), AX, BX
+	ADDQ  AX, R12
+	ADCQ  $0x00, BX
+	ADDQ  R14, R12
+	ADCQ  $0x00, BX
+	MOVQ  BX, R14
+	MULXQ 16(SI), AX, BX
+	ADDQ  AX, R13
+	ADCQ  $0x00, BX
+	ADDQ  R14, R13
+	ADCQ  $0x00, BX
+	MOVQ  BX, R14
+	MULXQ 24(SI), AX, BX
+	ADDQ  AX, R8
+	ADCQ  $0x00, BX
+	ADDQ  R14, R8
+	ADCQ  $0x00, BX
+	MOVQ  BX, R14
+	ADDQ  R14, R9
+	ADCQ  $0x00, R10
+	MOVQ  $0xfffffffeffffffff, R15
+	IMULQ R11, R15
+	MOVQ  R15, DX
+	MULXQ ·r64+0(SB), AX, BX
+	ADDQ  AX, R11
+	ADCQ  $0x00, BX
+	MOVQ  BX, R14
+	MULXQ ·r64+8(SB), AX, BX
+	ADDQ  AX, R12
+	ADCQ  $0x00, BX
+	ADDQ  R14, R12
+	ADCQ  $0x00, BX
+	MOVQ  BX, R14
+	MULXQ ·r64+16(SB), AX, BX
+	ADDQ  AX, R13
+	ADCQ  $0x00, BX
+	ADDQ  R14, R13
+	ADCQ  $0x00, BX
+	MOVQ  BX, R14
+	MULXQ ·r64+24(SB), AX, BX
+	ADDQ  AX, R8
+	ADCQ  $0x00, BX
+	ADDQ  R14, R8
+	ADCQ  $0x00, BX
+	MOVQ  BX, R14
+	ADDQ  R14, R9
+	ADCQ  $0x00, R10
+	JMP   out
+
+fallback:
+	MOVQ  (DI), AX
+	MULQ  (SI)
+	ADDQ  AX, R8
+	ADCQ  $0x00, DX
+	MOVQ  DX, R14
+	MOVQ  (DI), AX
+	MULQ  8(SI)
+	ADDQ  AX, R9
+	ADCQ  $0x00, DX
+	ADDQ  R14, R9
+	ADCQ  $0x00, DX
+	MOVQ  DX, R14
+	MOVQ  (DI), AX
+	MULQ  16(SI)
+	ADDQ  AX, R10
+	ADCQ  $0x00, DX
+	ADDQ  R14, R10
+	ADCQ  $0x00, DX
+	MOVQ  DX, R14
+	MOVQ  (DI), AX
+	MULQ  24(SI)
+	ADDQ  AX, R11
+	ADCQ  $0x00, DX
+	ADDQ  R14, R11
+	ADCQ  $0x00, DX
+	MOVQ  DX, R14
+	ADDQ  R14, R12
+	ADCQ  $0x00, R13
+	MOVQ  $0xfffffffeffffffff, R15
+	IMULQ R8, R15
+	MOVQ  R15, AX
+	MULQ  ·r64+0(SB)
+	ADDQ  AX, R8
+	ADCQ  $0x00, DX
+	MOVQ  DX, R14
+	MOVQ  R15, AX
+	MULQ  ·r64+8(SB)
+	ADDQ  AX, R9
+	ADCQ  $0x00, DX
+	ADDQ  R14, R9
+	ADCQ  $0x00, DX
+	MOVQ  DX, R14
+	MOVQ  R15, AX
+	MULQ  ·r64+16(SB)
+	ADDQ  AX, R10
+	ADCQ  $0x00, DX
+	ADDQ  R14, R10
+	ADCQ  $0x00, DX
+	MOVQ  DX, R14
+	MOVQ  R15, AX
+	MULQ  ·r64+24(SB)
+	ADDQ  AX, R11
+	ADCQ  $0x00, DX
+	ADDQ  R14, R11
+	ADCQ  $0x00, DX
+	MOVQ  DX, R14
+	ADDQ  R14, R12
+	ADCQ  $0x00, R13
+	MOVQ  8(DI), AX
+	MULQ  (SI)
+	ADDQ  AX, R9
+	ADCQ  $0x00, DX
+	MOVQ  DX, R14
+	MOVQ  8(DI), AX
+	MULQ  8(SI)
+	ADDQ  AX, R10
+	ADCQ  $0x00, DX
+	ADDQ  R14, R10
+	ADCQ  $0x00, DX
+	MOVQ  DX, R14
+	MOVQ  8(DI), AX
+	MULQ  16(SI)
+	ADDQ  AX, R11
+	ADCQ  $0x00, DX
+	ADDQ  R14, R11
+	ADCQ  $0x00, DX
+	MOVQ  DX, R14
+	MOVQ  8(DI), AX
+	MULQ  24(SI)
+	ADDQ  AX, R12
+	ADCQ  $0x00, DX
+	ADDQ  R14, R12
+	ADCQ  $0x00, DX
+	MOVQ  DX, R14
+	ADDQ  R14, R13
+	ADCQ  $0x00, R8
+	MOVQ  $0xfffffffeffffffff, R15
+	IMULQ R9, R15
+	MOVQ  R15, AX
+	MULQ  ·r64+0(SB)
+	ADDQ  AX, R9
+	ADCQ  $0x00, DX
+	MOVQ  DX, R14
+	MOVQ  R15, AX
+	MULQ  ·r64+8(SB)
+	ADDQ  AX, R10
+	ADCQ  $0x00, DX
+	ADDQ  R14, R10
+	ADCQ  $0x00, DX
+	MOVQ  DX, R14
+	MOVQ  R15, AX
+	MULQ  ·r64+16(SB)
+	ADDQ  AX, R11
+	ADCQ  $0x00, DX
+	ADDQ  R14, R11
+	ADCQ  $0x00, DX
+	MOVQ  DX, R14
+	MOVQ  R15, AX
+	MULQ  ·r64+24(SB)
+	ADDQ  AX, R12
+	ADCQ  $0x00, DX
+	ADDQ  R14, R12
+	ADCQ  $0x00, DX
+	MOVQ  DX, R14
+	ADDQ  R14, R13
+	ADCQ  $0x00, R8
+	MOVQ  16(DI), AX
+	MULQ  (SI)
+	ADDQ  AX, R10
+	ADCQ  $0x00, DX
+	MOVQ  DX, R14
+	MOVQ  16(DI), AX
+	MULQ  8(SI)
+	ADDQ  AX, R11
+	ADCQ  $0x00, DX
+	ADDQ  R14, R11
+	ADCQ  $0x00, DX
+	MOVQ  DX, R14
+	MOVQ  16(DI), AX
+	MULQ  16(SI)
+	ADDQ  AX, R12
+	ADCQ  $0x00, DX
+	ADDQ  R14, R12
+	ADCQ  $0x00, DX
+	MOVQ  DX, R14
+	MOVQ  16(DI), AX
+	MULQ  24(SI)
+	ADDQ  AX, R13
+	ADCQ  $0x00, DX
+	ADDQ  R14, R13
+	ADCQ  $0x00, DX
+	MOVQ  DX, R14
+	ADDQ  R14, R8
+	ADCQ  $0x00, R9
+	MOVQ  $0xfffffffeffffffff, R15
+	IMULQ R10, R15
+	MOVQ  R15, AX
+	MULQ  ·r64+0(SB)
+	ADDQ  AX, R10
+	ADCQ  $0x00, DX
+	MOVQ  DX, R14
+	MOVQ  R15, AX
+	MULQ  ·r64+8(SB)
+	ADDQ  AX, R11
+	ADCQ  $0x00, DX
+	ADDQ  R14, R11
+	ADCQ  $0x00, DX
+	MOVQ  DX, R14
+	MOVQ  R15, AX
+	MULQ  ·r64+16(SB)
+	ADDQ  AX, R12
+	ADCQ  $0x00, DX
+	ADDQ  R14, R12
+	ADCQ  $0x00, DX
+	MOVQ  DX, R14
+	MOVQ  R15, AX
+	MULQ  ·r64+24(SB)
+	ADDQ  AX, R13
+	ADCQ  $0x00, DX
+	ADDQ  R14, R13
+	ADCQ  $0x00, DX
+	MOVQ  DX, R14
+	ADDQ  R14, R8
+	ADCQ  $0x00, R9
+	MOVQ  24(DI), AX
+	MULQ  (SI)
+	ADDQ  AX, R11
+	ADCQ  $0x00, DX
+	MOVQ  DX, R14
+	MOVQ  24(DI), AX
+	MULQ  8(SI)
+	ADDQ  AX, R12
+	ADCQ  $0x00, DX
+	ADDQ  R14, R12
+	ADCQ  $0x00, DX
+	MOVQ  DX, R14
+	MOVQ  24(DI), AX
+	MULQ  16(SI)
+	ADDQ  AX, R13
+	ADCQ  $0x00, DX
+	ADDQ  R14, R13
+	ADCQ  $0x00, DX
+	MOVQ  DX, R14
+	MOVQ  24(DI), AX
+	MULQ  24(SI)
+	ADDQ  AX, R8
+	ADCQ  $0x00, DX
+	ADDQ  R14, R8
+	ADCQ  $0x00, DX
+	MOVQ  DX, R14
+	ADDQ  R14, R9
+	ADCQ  $0x00, R10
+	MOVQ  $0xfffffffeffffffff, R15
+	IMULQ R11, R15
+	MOVQ  R15, AX
+	MULQ  ·r64+0(SB)
+	ADDQ  AX, R11
+	ADCQ  $0x00, DX
+	MOVQ  DX, R14
+	MOVQ  R15, AX
+	MULQ  ·r64+8(SB)
+	ADDQ  AX, R12
+	ADCQ  $0x00, DX
+	ADDQ  R14, R12
+	ADCQ  $0x00, DX
+	MOVQ  DX, R14
+	MOVQ  R15, AX
+	MULQ  ·r64+16(SB)
+	ADDQ  AX, R13
+	ADCQ  $0x00, DX
+	ADDQ  R14, R13
+	ADCQ  $0x00, DX
+	MOVQ  DX, R14
+	MOVQ  R15, AX
+	MULQ  ·r64+24(SB)
+	ADDQ  AX, R8
+	ADCQ  $0x00, DX
+	ADDQ  R14, R8
+	ADCQ  $0x00, DX
+	MOVQ  DX, R14
+	ADDQ  R14, R9
+	ADCQ  $0x00, R10
+
+out:
+	MOVQ    R12, R14
+	MOVQ    R13, R15
+	MOVQ    R8, BX
+	MOVQ    R9, CX
+	SUBQ    ·r64+0(SB), R14
+	SBBQ    ·r64+8(SB), R15
+	SBBQ    ·r64+16(SB), BX
+	SBBQ    ·r64+24(SB), CX
+	CMOVQCC R14, R12
+	CMOVQCC R15, R13
+	CMOVQCC BX, R8
+	CMOVQCC CX, R9
+	MOVQ    z+0(FP), SI
+	MOVQ    R12, (SI)
+	MOVQ    R13, 8(SI)
+	MOVQ    R8, 16(SI)
+	MOVQ    R9, 24(SI)
+	RET
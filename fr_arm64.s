@@ -0,0 +1,162 @@
+// +build arm64asm,!generic
+
+#include "textflag.h"
+
+// frMod subtracts r, held in R16, R17, R19, R20, from t0..t3 if t0..t3 is at
+// least r, in constant time.
+#define frMod(t0, t1, t2, t3) \
+	SUBS R16, t0, R10; \
+	SBCS R17, t1, R11; \
+	SBCS R19, t2, R12; \
+	SBCS R20, t3, R13; \
+	CSEL CS, R10, t0, t0; \
+	CSEL CS, R11, t1, t1; \
+	CSEL CS, R12, t2, t2; \
+	CSEL CS, R13, t3, t3
+
+// frLoadR loads r into R16, R17, R19, R20.
+#define frLoadR \
+	MOVD $·r64(SB), R10; \
+	LDP  0(R10), (R16, R17); \
+	LDP  16(R10), (R19, R20)
+
+// frMulStep adds a*(b0..b3) to t0..t3 and propagates the carry into t4 and
+// t5. R21 and R22 hold the halves of the products and R23 the carries between
+// words.
+#define frMulStep(a, b0, b1, b2, b3, t0, t1, t2, t3, t4, t5) \
+	MUL   b0, a, R21; \
+	UMULH b0, a, R22; \
+	ADDS  R21, t0, t0; \
+	ADC   ZR, R22, R23; \
+	MUL   b1, a, R21; \
+	UMULH b1, a, R22; \
+	ADDS  R21, t1, t1; \
+	ADC   ZR, R22, R22; \
+	ADDS  R23, t1, t1; \
+	ADC   ZR, R22, R23; \
+	MUL   b2, a, R21; \
+	UMULH b2, a, R22; \
+	ADDS  R21, t2, t2; \
+	ADC   ZR, R22, R22; \
+	ADDS  R23, t2, t2; \
+	ADC   ZR, R22, R23; \
+	MUL   b3, a, R21; \
+	UMULH b3, a, R22; \
+	ADDS  R21, t3, t3; \
+	ADC   ZR, R22, R22; \
+	ADDS  R23, t3, t3; \
+	ADC   ZR, R22, R23; \
+	ADDS  R23, t4, t4; \
+	ADC   ZR, t5, t5
+
+// frMulRound adds x*yi, with x in R8, R9, R14, R15, to t0..t5 and then m*r,
+// where m makes t0 0, so that t0..t5 can be shifted by a word. rK64 is held in
+// R24.
+#define frMulRound(yi, t0, t1, t2, t3, t4, t5) \
+	frMulStep(yi, R8, R9, R14, R15, t0, t1, t2, t3, t4, t5); \
+	MUL R24, t0, R25; \
+	frMulStep(R25, R16, R17, R19, R20, t0, t1, t2, t3, t4, t5)
+
+// func frAdd(z *[4]uint64, x *[4]uint64, y *[4]uint64)
+TEXT ·frAdd(SB), NOSPLIT, $0-24
+	MOVD x+8(FP), R0
+	MOVD y+16(FP), R1
+	LDP  0(R0), (R2, R3)
+	LDP  16(R0), (R4, R5)
+	LDP  0(R1), (R6, R7)
+	LDP  16(R1), (R8, R9)
+
+	ADDS R6, R2, R2
+	ADCS R7, R3, R3
+	ADCS R8, R4, R4
+	ADC  R9, R5, R5
+
+	frLoadR
+	frMod(R2, R3, R4, R5)
+
+	MOVD z+0(FP), R0
+	STP  (R2, R3), 0(R0)
+	STP  (R4, R5), 16(R0)
+	RET
+
+// func frNeg(z *[4]uint64, x *[4]uint64)
+TEXT ·frNeg(SB), NOSPLIT, $0-16
+	MOVD x+8(FP), R0
+	LDP  0(R0), (R6, R7)
+	LDP  16(R0), (R8, R9)
+
+	frLoadR
+	SUBS R6, R16, R2
+	SBCS R7, R17, R3
+	SBCS R8, R19, R4
+	SBC  R9, R20, R5
+
+	// -0 is r before the reduction.
+	frMod(R2, R3, R4, R5)
+
+	MOVD z+0(FP), R0
+	STP  (R2, R3), 0(R0)
+	STP  (R4, R5), 16(R0)
+	RET
+
+// func frSub(z *[4]uint64, x *[4]uint64, y *[4]uint64)
+TEXT ·frSub(SB), NOSPLIT, $0-24
+	MOVD x+8(FP), R0
+	MOVD y+16(FP), R1
+	LDP  0(R0), (R2, R3)
+	LDP  16(R0), (R4, R5)
+	LDP  0(R1), (R6, R7)
+	LDP  16(R1), (R8, R9)
+
+	SUBS R6, R2, R2
+	SBCS R7, R3, R3
+	SBCS R8, R4, R4
+	SBCS R9, R5, R5
+
+	// if x < y, then r is added back.
+	frLoadR
+	CSEL CS, ZR, R16, R16
+	CSEL CS, ZR, R17, R17
+	CSEL CS, ZR, R19, R19
+	CSEL CS, ZR, R20, R20
+	ADDS R16, R2, R2
+	ADCS R17, R3, R3
+	ADCS R19, R4, R4
+	ADC  R20, R5, R5
+
+	MOVD z+0(FP), R0
+	STP  (R2, R3), 0(R0)
+	STP  (R4, R5), 16(R0)
+	RET
+
+// func frMul(z *[4]uint64, x *[4]uint64, y *[4]uint64)
+TEXT ·frMul(SB), NOSPLIT, $0-24
+	MOVD x+8(FP), R0
+	MOVD y+16(FP), R1
+	frLoadR
+	LDP  0(R0), (R8, R9)
+	LDP  16(R0), (R14, R15)
+	LDP  0(R1), (R10, R11)
+	LDP  16(R1), (R12, R13)
+	MOVD $0xfffffffeffffffff, R24
+
+	MOVD ZR, R2
+	MOVD ZR, R3
+	MOVD ZR, R4
+	MOVD ZR, R5
+	MOVD ZR, R6
+	MOVD ZR, R7
+
+	// the register of the lowest word, 0 after each round, becomes the
+	// highest word of the next round.
+	frMulRound(R10, R2, R3, R4, R5, R6, R7)
+	frMulRound(R11, R3, R4, R5, R6, R7, R2)
+	frMulRound(R12, R4, R5, R6, R7, R2, R3)
+	frMulRound(R13, R5, R6, R7, R2, R3, R4)
+
+	frMod(R6, R7, R2, R3)
+
+	MOVD z+0(FP), R0
+	STP  (R6, R7), 0(R0)
+	STP  (R2, R3), 16(R0)
+	RET
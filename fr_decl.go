@@ -0,0 +1,19 @@
+// +build amd64,!generic arm64,arm64asm,!generic
+
+package bls12
+
+// frAdd sets z to the sum x+y.
+//go:noescape
+func frAdd(z, x, y *Fr)
+
+// frNeg sets z to -x.
+//go:noescape
+func frNeg(z, x *Fr)
+
+// frSub sets z to the difference x-y.
+//go:noescape
+func frSub(z, x, y *Fr)
+
+// frMul sets z to the product x*y.
+//go:noescape
+func frMul(z, x, y *Fr)
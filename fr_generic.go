@@ -0,0 +1,89 @@
+// +build !amd64,!arm64 !amd64,!arm64asm generic
+
+package bls12
+
+import "math/bits"
+
+// frReduce sets z to x mod r, where x = hi 2^256 + x[:] is smaller than 2r.
+func frReduce(z *Fr, x *Fr, hi uint64) {
+	var d Fr
+	var borrow uint64
+	for i := range d {
+		d[i], borrow = bits.Sub64(x[i], r64[i], borrow)
+	}
+	_, borrow = bits.Sub64(hi, 0, borrow)
+	frCMov(z, &d, x, borrow)
+}
+
+// frAdd sets z to the sum x+y.
+func frAdd(z, x, y *Fr) {
+	var s Fr
+	var carry uint64
+	for i := range s {
+		s[i], carry = bits.Add64(x[i], y[i], carry)
+	}
+	frReduce(z, &s, carry)
+}
+
+// frSub sets z to the difference x-y.
+func frSub(z, x, y *Fr) {
+	var d Fr
+	var borrow uint64
+	for i := range d {
+		d[i], borrow = bits.Sub64(x[i], y[i], borrow)
+	}
+
+	// if x < y, then r is added back.
+	mask := -borrow
+	var carry uint64
+	for i := range d {
+		z[i], carry = bits.Add64(d[i], r64[i]&mask, carry)
+	}
+}
+
+// frNeg sets z to -x.
+func frNeg(z, x *Fr) {
+	var d Fr
+	var borrow uint64
+	for i := range d {
+		d[i], borrow = bits.Sub64(r64[i], x[i], borrow)
+	}
+	frReduce(z, &d, 0)
+}
+
+// frMul sets z to the product x*y, with the interleaved montgomery
+// multiplication and reduction.
+// See https://www.microsoft.com/en-us/research/wp-content/uploads/1996/01/j37acmon.pdf - CIOS.
+func frMul(z, x, y *Fr) {
+	var t [frLen + 2]uint64
+	for i := 0; i < frLen; i++ {
+		// t += x y[i]
+		var c, hi, lo, carry uint64
+		for j := 0; j < frLen; j++ {
+			hi, lo = bits.Mul64(x[j], y[i])
+			lo, carry = bits.Add64(lo, t[j], 0)
+			hi += carry
+			t[j], carry = bits.Add64(lo, c, 0)
+			c = hi + carry
+		}
+		t[frLen], carry = bits.Add64(t[frLen], c, 0)
+		t[frLen+1] = carry
+
+		// t = (t + m r) / 2^64, where m is chosen so that the division is exact.
+		m := t[0] * rK64
+		hi, lo = bits.Mul64(m, r64[0])
+		_, carry = bits.Add64(lo, t[0], 0)
+		c = hi + carry
+		for j := 1; j < frLen; j++ {
+			hi, lo = bits.Mul64(m, r64[j])
+			lo, carry = bits.Add64(lo, t[j], 0)
+			hi += carry
+			t[j-1], carry = bits.Add64(lo, c, 0)
+			c = hi + carry
+		}
+		t[frLen-1], carry = bits.Add64(t[frLen], c, 0)
+		t[frLen] = t[frLen+1] + carry
+	}
+
+	frReduce(z, &Fr{t[0], t[1], t[2], t[3]}, t[frLen])
+}
@@ -0,0 +1,249 @@
+package bls12
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+)
+
+func TestFrSetInt(t *testing.T) {
+	for name, scalar := range scalarMultTests() {
+		t.Run(name, func(t *testing.T) {
+			want := new(big.Int).Mod(scalar, r)
+			if got := new(Fr).SetInt(scalar).Int(); got.Cmp(want) != 0 {
+				t.Fatalf("expected: %v, got: %v", want, got)
+			}
+		})
+	}
+}
+
+func TestFrSetUint64(t *testing.T) {
+	tests := map[string]struct {
+		input uint64
+		want  Fr
+	}{
+		"zero": {input: 0, want: Fr{}},
+		"one":  {input: 1, want: *frOne},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := new(Fr).SetUint64(tc.input)
+			if *got != tc.want {
+				t.Fatalf("expected: %v, got: %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestFrArithmetic(t *testing.T) {
+	ops := map[string]struct {
+		fr  func(z, x, y *Fr) *Fr
+		big func(z, x, y *big.Int) *big.Int
+	}{
+		"add":    {fr: (*Fr).Add, big: (*big.Int).Add},
+		"sub":    {fr: (*Fr).Sub, big: (*big.Int).Sub},
+		"mul":    {fr: (*Fr).Mul, big: (*big.Int).Mul},
+		"neg":    {fr: func(z, x, _ *Fr) *Fr { return z.Neg(x) }, big: func(z, x, _ *big.Int) *big.Int { return z.Neg(x) }},
+		"square": {fr: func(z, x, _ *Fr) *Fr { return z.Square(x) }, big: func(z, x, _ *big.Int) *big.Int { return z.Mul(x, x) }},
+	}
+	scalars := scalarMultTests()
+	for name, op := range ops {
+		t.Run(name, func(t *testing.T) {
+			for _, a := range scalars {
+				for _, b := range scalars {
+					want := op.big(new(big.Int), a, b)
+					want.Mod(want, r)
+					got := op.fr(new(Fr), new(Fr).SetInt(a), new(Fr).SetInt(b)).Int()
+					if got.Cmp(want) != 0 {
+						t.Fatalf("%v, %v: expected: %v, got: %v", a, b, want, got)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestFrInv(t *testing.T) {
+	for name, scalar := range scalarMultTests() {
+		t.Run(name, func(t *testing.T) {
+			want := new(big.Int).ModInverse(new(big.Int).Mod(scalar, r), r)
+			if want == nil {
+				want = new(big.Int)
+			}
+			if got := new(Fr).Inv(new(Fr).SetInt(scalar)).Int(); got.Cmp(want) != 0 {
+				t.Fatalf("expected: %v, got: %v", want, got)
+			}
+		})
+	}
+}
+
+func TestFrExp(t *testing.T) {
+	x := new(Fr).SetUint64(3)
+	tests := map[string]*big.Int{
+		"zero":     big.NewInt(0),
+		"one":      big.NewInt(1),
+		"r - 1":    new(big.Int).Sub(r, big.NewInt(1)),
+		"2^300":    new(big.Int).Lsh(big.NewInt(1), 300),
+		"negative": big.NewInt(-7),
+	}
+	for name, y := range tests {
+		t.Run(name, func(t *testing.T) {
+			want := new(big.Int).Exp(big.NewInt(3), y, r)
+			if got := new(Fr).Exp(x, y).Int(); got.Cmp(want) != 0 {
+				t.Fatalf("expected: %v, got: %v", want, got)
+			}
+		})
+	}
+}
+
+func TestFrSqrt(t *testing.T) {
+	for name, scalar := range scalarMultTests() {
+		t.Run(name, func(t *testing.T) {
+			x := new(Fr).SetInt(scalar)
+			want := new(big.Int).ModSqrt(x.Int(), r)
+			got := new(Fr).Sqrt(x)
+			if (got == nil) != (want == nil) {
+				t.Fatalf("expected: %v, got: %v", want, got)
+			}
+			if got != nil && !new(Fr).Square(got).Equal(x) {
+				t.Fatalf("%v is not a square root of %v", got, x)
+			}
+		})
+	}
+	t.Run("non-square", func(t *testing.T) {
+		z := new(Fr).SetOne()
+		if got := z.Sqrt(new(Fr).SetUint64(7)); got != nil {
+			t.Fatalf("expected: nil, got: %v", got)
+		}
+		if !z.Equal(frOne) {
+			t.Fatalf("expected z unchanged, got: %v", z)
+		}
+	})
+}
+
+func TestFrSetBytes(t *testing.T) {
+	tests := map[string]string{
+		"empty":   "",
+		"one":     "01",
+		"9 bytes": "0102030405060708ff",
+		"r":       "73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001",
+		"64 bytes": "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff" +
+			"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+	}
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			b, _ := hex.DecodeString(input)
+			want := new(big.Int).SetBytes(b)
+			want.Mod(want, r)
+			if got := new(Fr).SetBytes(b).Int(); got.Cmp(want) != 0 {
+				t.Fatalf("expected: %v, got: %v", want, got)
+			}
+		})
+	}
+}
+
+func TestFrSetCanonicalBytes(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		err   error
+	}{
+		"zero":      {input: "0000000000000000000000000000000000000000000000000000000000000000"},
+		"r - 1":     {input: "73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000000"},
+		"r":         {input: "73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001", err: ErrNonCanonical},
+		"2^256 - 1": {input: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", err: ErrNonCanonical},
+		"too short": {input: "01", err: ErrInvalidFrLength},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			b, _ := hex.DecodeString(tc.input)
+			got, err := new(Fr).SetCanonicalBytes(b)
+			if err != tc.err {
+				t.Fatalf("expected: %v, got: %v", tc.err, err)
+			}
+			if err == nil && !bytes.Equal(got.Bytes(), b) {
+				t.Fatalf("expected: %x, got: %x", b, got.Bytes())
+			}
+		})
+	}
+}
+
+func TestRandFr(t *testing.T) {
+	x, err := RandFr(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	y, err := RandFr(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x.IsZero() || x.Equal(y) {
+		t.Fatalf("expected distinct non-zero scalars, got: %v, %v", x, y)
+	}
+	if _, err := RandFr(bytes.NewReader(make([]byte, 10))); err == nil {
+		t.Fatal("expected an error for a short reader")
+	}
+}
+
+// FuzzFrArithmetic checks the arithmetic routines of the build against
+// math/big, for all the code paths of the build. Running it with the generic
+// build tag and on arm64 with the arm64asm build tag cross-checks the three
+// implementations.
+func FuzzFrArithmetic(f *testing.F) {
+	rMinusOne := new(big.Int).Sub(r, big.NewInt(1))
+	seeds := [][]byte{{}, {1}, rMinusOne.Bytes(), r.Bytes(), bytes.Repeat([]byte{0xff}, frByteLen)}
+	for _, a := range seeds {
+		for _, b := range seeds {
+			f.Add(a, b)
+		}
+	}
+
+	rInv := new(big.Int).ModInverse(new(big.Int).Lsh(big.NewInt(1), frLen*wordSize), r)
+	f.Fuzz(func(t *testing.T, a, b []byte) {
+		// the routines operate on the words of x and y, whatever their
+		// montgomery value.
+		bigX, bigY := new(big.Int).SetBytes(a), new(big.Int).SetBytes(b)
+		bigX.Mod(bigX, r)
+		bigY.Mod(bigY, r)
+		x, y := Fr(ctScalar(bigX)), Fr(ctScalar(bigY))
+
+		mul := new(big.Int).Mul(bigX, bigY)
+		tests := map[string]struct {
+			f    func(z *Fr)
+			want *big.Int
+		}{
+			"add": {f: func(z *Fr) { frAdd(z, &x, &y) }, want: new(big.Int).Add(bigX, bigY)},
+			"sub": {f: func(z *Fr) { frSub(z, &x, &y) }, want: new(big.Int).Sub(bigX, bigY)},
+			"neg": {f: func(z *Fr) { frNeg(z, &x) }, want: new(big.Int).Neg(bigX)},
+			"mul": {f: func(z *Fr) { frMul(z, &x, &y) }, want: mul.Mul(mul, rInv)},
+		}
+		forEachBackend(func(backend string) {
+			for name, tc := range tests {
+				var z Fr
+				tc.f(&z)
+				want := tc.want.Mod(tc.want, r)
+				if got := wordsToInt(z[:]); got.Cmp(want) != 0 {
+					t.Fatalf("%s, %s: %x, %x: expected: %x, got: %x", backend, name, bigX, bigY, want, got)
+				}
+			}
+		})
+	})
+}
+
+func BenchmarkFrMul(b *testing.B) {
+	x, _ := RandFr(rand.Reader)
+	y, _ := RandFr(rand.Reader)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Mul(x, y)
+	}
+}
+
+func BenchmarkFrInv(b *testing.B) {
+	x, _ := RandFr(rand.Reader)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Inv(x)
+	}
+}
@@ -2,6 +2,7 @@ package bls12
 
 import (
 	"math/big"
+	"sync"
 )
 
 var g1Gen = &G1Point{curvePoint{
@@ -15,14 +16,179 @@ type G1Point struct {
 }
 
 // ScalarBaseMult returns k*G, where G is the base point of the group
-// and k is an integer in big-endian form.
+// and k is an integer in big-endian form. The multiplication runs in constant
+// time with respect to k, but the conversion of the big.Int leaks the length of
+// k: secret scalars should be given to ScalarBaseMultFr.
 func (z *G1Point) ScalarBaseMult(scalar *big.Int) *G1Point {
-	return z.ScalarMult(g1Gen, scalar)
+	return z.ScalarMultFixedBase(g1GenFixedBase(), scalar)
 }
 
-// ScalarMult returns k*(Bx,By) where k is a number in big-endian form.
+// ScalarBaseMultFr is like ScalarBaseMult with a scalar of Fr.
+func (z *G1Point) ScalarBaseMultFr(scalar *Fr) *G1Point {
+	return z.ScalarMultFixedBaseFr(g1GenFixedBase(), scalar)
+}
+
+// G1FixedBase holds precomputed multiples of a fixed point of G1, such as
+// a commitment generator, to speed up its scalar multiplications.
+type G1FixedBase struct {
+	t curveFixedBase
+}
+
+var (
+	g1GenTable     *G1FixedBase
+	g1GenTableOnce sync.Once
+)
+
+// g1GenFixedBase returns the table of the generator, which is built on the
+// first use.
+func g1GenFixedBase() *G1FixedBase {
+	g1GenTableOnce.Do(func() {
+		g1GenTable = NewG1FixedBase(g1Gen)
+	})
+	return g1GenTable
+}
+
+// NewG1FixedBase returns the table of x. x must be in G1. Building the table
+// costs about as much as ten scalar multiplications, so it is only worth it for
+// points that are multiplied many times.
+func NewG1FixedBase(x *G1Point) *G1FixedBase {
+	b := new(G1FixedBase)
+	b.t.Init(&x.p)
+	return b
+}
+
+// ScalarMultFixedBase returns k*(Bx,By), where (Bx,By) is the point of the table
+// b and k is a number in big-endian form. The multiplication runs in constant
+// time with respect to k, but the conversion of the big.Int leaks the length of
+// k: secret scalars should be given to ScalarMultFixedBaseFr.
+func (z *G1Point) ScalarMultFixedBase(b *G1FixedBase, scalar *big.Int) *G1Point {
+	k := ctScalar(scalar)
+	z.p.ScalarMultFixedBase(&b.t, &k)
+	return z
+}
+
+// ScalarMultFixedBaseFr is like ScalarMultFixedBase with a scalar of Fr.
+func (z *G1Point) ScalarMultFixedBaseFr(b *G1FixedBase, scalar *Fr) *G1Point {
+	k := scalar.words()
+	z.p.ScalarMultFixedBase(&b.t, &k)
+	return z
+}
+
+// ScalarMult returns k*(Bx,By) where k is a number in big-endian form. x must
+// be in G1.
 func (z *G1Point) ScalarMult(x *G1Point, scalar *big.Int) *G1Point {
-	z.p.ScalarMult(&x.p, scalar)
+	k := ctScalar(scalar)
+	z.p.ScalarMultGLV(&x.p, &k)
+	return z
+}
+
+// ScalarMultFr is like ScalarMult with a scalar of Fr.
+func (z *G1Point) ScalarMultFr(x *G1Point, scalar *Fr) *G1Point {
+	k := scalar.words()
+	z.p.ScalarMultGLV(&x.p, &k)
+	return z
+}
+
+// ScalarMultCT returns k*(Bx,By) where k is a number in big-endian form. The
+// multiplication runs in constant time with respect to k, but the conversion of
+// the big.Int leaks the length of k: secret scalars should be given to
+// ScalarMultCTFr. x must be in G1.
+func (z *G1Point) ScalarMultCT(x *G1Point, scalar *big.Int) *G1Point {
+	k := ctScalar(scalar)
+	z.p.ScalarMultCT(&x.p, &k)
+	return z
+}
+
+// ScalarMultCTFr is like ScalarMultCT with a scalar of Fr.
+func (z *G1Point) ScalarMultCTFr(x *G1Point, scalar *Fr) *G1Point {
+	k := scalar.words()
+	z.p.ScalarMultCT(&x.p, &k)
+	return z
+}
+
+// DoubleScalarMult returns a*p + b*q, where a and b are numbers in big-endian
+// form, faster than two separate scalar multiplications. p and q must be in
+// G1.
+func (z *G1Point) DoubleScalarMult(a *big.Int, p *G1Point, b *big.Int, q *G1Point) *G1Point {
+	ka, kb := ctScalar(a), ctScalar(b)
+	z.p.DoubleScalarMultGLV(&ka, &p.p, &kb, &q.p)
+	return z
+}
+
+// DoubleScalarMultFr is like DoubleScalarMult with scalars of Fr.
+func (z *G1Point) DoubleScalarMultFr(a *Fr, p *G1Point, b *Fr, q *G1Point) *G1Point {
+	ka, kb := a.words(), b.words()
+	z.p.DoubleScalarMultGLV(&ka, &p.p, &kb, &q.p)
+	return z
+}
+
+// DoubleScalarMultCT returns a*p + b*q, where a and b are numbers in
+// big-endian form. The multiplication runs in constant time with respect to a
+// and b, but the conversion of the big.Ints leaks their lengths: secret scalars
+// should be given to DoubleScalarMultCTFr. p and q must be in G1.
+func (z *G1Point) DoubleScalarMultCT(a *big.Int, p *G1Point, b *big.Int, q *G1Point) *G1Point {
+	ka, kb := ctScalar(a), ctScalar(b)
+	z.p.DoubleScalarMultCT(&ka, &p.p, &kb, &q.p)
+	return z
+}
+
+// DoubleScalarMultCTFr is like DoubleScalarMultCT with scalars of Fr.
+func (z *G1Point) DoubleScalarMultCTFr(a *Fr, p *G1Point, b *Fr, q *G1Point) *G1Point {
+	ka, kb := a.words(), b.words()
+	z.p.DoubleScalarMultCT(&ka, &p.p, &kb, &q.p)
+	return z
+}
+
+// G1MultiExp returns the sum of scalars[i]*points[i], where the scalars are
+// numbers in big-endian form. The points must be in G1. G1MultiExp is much
+// faster than separate scalar multiplications for many points and uses as many
+// goroutines as GOMAXPROCS.
+func G1MultiExp(points []*G1Point, scalars []*big.Int) (*G1Point, error) {
+	return G1MultiExpWithWorkers(points, scalars, multiExpWorkers())
+}
+
+// G1MultiExpWithWorkers is like G1MultiExp but spreads the work across the
+// given number of goroutines.
+func G1MultiExpWithWorkers(points []*G1Point, scalars []*big.Int, workers int) (*G1Point, error) {
+	if len(points) != len(scalars) {
+		return nil, ErrMismatchedLengths
+	}
+
+	ks := make([][scalarWords]uint64, len(scalars))
+	for i, scalar := range scalars {
+		ks[i] = ctScalar(scalar)
+	}
+	return g1MultiExp(points, ks, workers), nil
+}
+
+// G1MultiExpFr is like G1MultiExp with scalars of Fr.
+func G1MultiExpFr(points []*G1Point, scalars []*Fr) (*G1Point, error) {
+	return G1MultiExpFrWithWorkers(points, scalars, multiExpWorkers())
+}
+
+// G1MultiExpFrWithWorkers is like G1MultiExpWithWorkers with scalars of Fr.
+func G1MultiExpFrWithWorkers(points []*G1Point, scalars []*Fr, workers int) (*G1Point, error) {
+	if len(points) != len(scalars) {
+		return nil, ErrMismatchedLengths
+	}
+
+	ks := make([][scalarWords]uint64, len(scalars))
+	for i, scalar := range scalars {
+		ks[i] = scalar.words()
+	}
+	return g1MultiExp(points, ks, workers), nil
+}
+
+// g1MultiExp returns the sum of ks[i]*points[i], where the scalars are
+// reduced modulo r.
+func g1MultiExp(points []*G1Point, ks [][scalarWords]uint64, workers int) *G1Point {
+	ps := make([]*curvePoint, len(points))
+	for i, point := range points {
+		ps[i] = &point.p
+	}
+
+	z := new(G1Point)
+	z.p.MultiExp(ps, ks, workers)
 	return z
 }
 
@@ -32,11 +198,41 @@ func (z *G1Point) Add(x, y *G1Point) *G1Point {
 	return z
 }
 
-// HashToPoint uses the Shallue and van de Woestijne encoding.
-// The point is guaranteed to be in the subgroup.
+// Neg returns -x.
+func (z *G1Point) Neg(x *G1Point) *G1Point {
+	z.p.Neg(&x.p)
+	return z
+}
+
+// HashToPoint hashes buf to a point of the group using the default domain
+// separation tag. The point is guaranteed to be in the subgroup.
 func (z *G1Point) HashToPoint(buf []byte) *G1Point {
-	z.p.HashToPoint(buf)
-	return z.ScalarMult(z, g1Cofactor)
+	return z.HashToPointWithDomain(buf, g1Domain)
+}
+
+// HashToPointWithDomain hashes buf to a point of the group using the domain
+// separation tag dst. The point is guaranteed to be in the subgroup. The
+// cofactor is cleared with h_eff, as in RFC 9380, and not with the cofactor h
+// used by earlier versions of this package, so the hashes differ from theirs.
+func (z *G1Point) HashToPointWithDomain(buf, dst []byte) *G1Point {
+	z.p.HashToPoint(buf, dst)
+	z.p.ClearCofactor(&z.p)
+	return z
+}
+
+// EncodeToPoint encodes buf to a point of the group using the default domain
+// separation tag. The point is guaranteed to be in the subgroup. The encoding
+// is cheaper than HashToPoint but its output is not uniformly distributed.
+func (z *G1Point) EncodeToPoint(buf []byte) *G1Point {
+	return z.EncodeToPointWithDomain(buf, g1EncodeDomain)
+}
+
+// EncodeToPointWithDomain encodes buf to a point of the group using the domain
+// separation tag dst. The point is guaranteed to be in the subgroup.
+func (z *G1Point) EncodeToPointWithDomain(buf, dst []byte) *G1Point {
+	z.p.EncodeToPoint(buf, dst)
+	z.p.ClearCofactor(&z.p)
+	return z
 }
 
 func (z *G1Point) ToAffine() *G1Point {
@@ -44,10 +240,52 @@ func (z *G1Point) ToAffine() *G1Point {
 	return z
 }
 
+// IsInSubgroup reports whether z is in the subgroup of order r. Points
+// obtained through Unmarshal or hashing are always in the subgroup.
+func (z *G1Point) IsInSubgroup() bool {
+	return z.p.IsInSubgroup()
+}
+
+// Marshal converts z into the 96-byte uncompressed form, compatible with the
+// Zcash serialization of BLS12-381 points.
 func (z *G1Point) Marshal() []byte {
 	return z.p.Marshal()
 }
 
+// MarshalCompressed converts z into the 48-byte compressed form, compatible
+// with the Zcash serialization of BLS12-381 points.
+func (z *G1Point) MarshalCompressed() []byte {
+	return z.p.MarshalCompressed()
+}
+
+// Unmarshal sets z to the result of converting the output of Marshal back into
+// a group element. The encoding must be canonical and the point must be in the
+// subgroup. On error, z is left unchanged.
 func (z *G1Point) Unmarshal(data []byte) error {
-	return z.p.Unmarshal(data)
+	p := new(curvePoint)
+	if err := p.Unmarshal(data); err != nil {
+		return err
+	}
+	if !p.IsInSubgroup() {
+		return ErrNotInSubgroup
+	}
+	z.p.Set(p)
+
+	return nil
+}
+
+// UnmarshalCompressed sets z to the result of converting the output of
+// MarshalCompressed back into a group element. The encoding must be canonical
+// and the point must be in the subgroup. On error, z is left unchanged.
+func (z *G1Point) UnmarshalCompressed(data []byte) error {
+	p := new(curvePoint)
+	if err := p.UnmarshalCompressed(data); err != nil {
+		return err
+	}
+	if !p.IsInSubgroup() {
+		return ErrNotInSubgroup
+	}
+	z.p.Set(p)
+
+	return nil
 }
@@ -1,32 +1,544 @@
 package bls12
 
 import (
+	"bytes"
 	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math/big"
 	"testing"
 )
 
 func TestG1PointScalarBaseMult(t *testing.T) {
-	// TODO
+	for name, tc := range g1Uncompressed {
+		t.Run(name, func(t *testing.T) {
+			want, _ := hex.DecodeString(tc.data)
+			got := new(G1Point).ScalarBaseMult(big.NewInt(tc.scalar)).Marshal()
+			if !bytes.Equal(got, want) {
+				t.Fatalf("expected: %x, got: %x", want, got)
+			}
+		})
+	}
+}
+
+// scalarMultTests returns edge case and random scalars for the scalar
+// multiplication tests.
+func scalarMultTests() map[string]*big.Int {
+	u2 := new(big.Int).Mul(bigU, bigU)
+	tests := map[string]*big.Int{
+		"zero":      big.NewInt(0),
+		"one":       big.NewInt(1),
+		"x²":        u2,
+		"x² - 1":    new(big.Int).Sub(u2, big.NewInt(1)),
+		"r - 1":     new(big.Int).Sub(r, big.NewInt(1)),
+		"r":         r,
+		"r + 1":     new(big.Int).Add(r, big.NewInt(1)),
+		"negative":  big.NewInt(-5),
+		"2^256 - 1": new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1)),
+	}
+	for i := 0; i < 8; i++ {
+		k, _ := randInt(rand.Reader, r)
+		tests[fmt.Sprintf("random %d", i)] = k
+	}
+	return tests
 }
 
 func TestG1PointScalarMult(t *testing.T) {
-	// TODO
+	p := new(G1Point).HashToPoint([]byte("abc"))
+	for name, scalar := range scalarMultTests() {
+		t.Run(name, func(t *testing.T) {
+			k := new(big.Int).Mod(scalar, r)
+			want := (&G1Point{*new(curvePoint).ScalarMult(&p.p, k)}).Marshal()
+			got := new(G1Point).ScalarMult(p, scalar).Marshal()
+			if !bytes.Equal(got, want) {
+				t.Fatalf("expected: %x, got: %x", want, got)
+			}
+		})
+	}
+}
+
+func TestG1PointDoubleScalarMult(t *testing.T) {
+	p := new(G1Point).HashToPoint([]byte("abc"))
+	q := new(G1Point).HashToPoint([]byte("def"))
+	scalars := scalarMultTests()
+	for name, a := range scalars {
+		b := scalars["random 0"]
+		if name == "random 0" {
+			b = scalars["r - 1"]
+		}
+		t.Run(name, func(t *testing.T) {
+			pa := new(curvePoint).ScalarMult(&p.p, new(big.Int).Mod(a, r))
+			qb := new(curvePoint).ScalarMult(&q.p, new(big.Int).Mod(b, r))
+			want := (&G1Point{*pa.Add(pa, qb)}).Marshal()
+			if got := new(G1Point).DoubleScalarMult(a, p, b, q).Marshal(); !bytes.Equal(got, want) {
+				t.Fatalf("expected: %x, got: %x", want, got)
+			}
+			if got := new(G1Point).DoubleScalarMultCT(a, p, b, q).Marshal(); !bytes.Equal(got, want) {
+				t.Fatalf("CT: expected: %x, got: %x", want, got)
+			}
+		})
+	}
+	t.Run("same point", func(t *testing.T) {
+		// a*p + (r-a)*p = 0
+		a := scalars["random 1"]
+		b := new(big.Int).Sub(r, a)
+		if got := new(G1Point).DoubleScalarMult(a, p, b, p); !got.p.IsInfinity() {
+			t.Fatalf("expected: infinity, got: %v", got)
+		}
+		if got := new(G1Point).DoubleScalarMultCT(a, p, b, p); !got.p.IsInfinity() {
+			t.Fatalf("CT: expected: infinity, got: %v", got)
+		}
+	})
+}
+
+func TestG1PointScalarMultFixedBase(t *testing.T) {
+	p := new(G1Point).HashToPoint([]byte("abc"))
+	table := NewG1FixedBase(p)
+	for name, scalar := range scalarMultTests() {
+		t.Run(name, func(t *testing.T) {
+			k := new(big.Int).Mod(scalar, r)
+			want := (&G1Point{*new(curvePoint).ScalarMult(&p.p, k)}).Marshal()
+			got := new(G1Point).ScalarMultFixedBase(table, scalar).Marshal()
+			if !bytes.Equal(got, want) {
+				t.Fatalf("expected: %x, got: %x", want, got)
+			}
+		})
+	}
+	t.Run("infinity", func(t *testing.T) {
+		got := new(G1Point).ScalarMultFixedBase(NewG1FixedBase(new(G1Point)), big.NewInt(5))
+		if !got.p.IsInfinity() {
+			t.Fatalf("expected: infinity, got: %v", got)
+		}
+	})
+}
+
+func TestG1PointScalarMultCT(t *testing.T) {
+	p := new(G1Point).HashToPoint([]byte("abc"))
+	for name, scalar := range scalarMultTests() {
+		t.Run(name, func(t *testing.T) {
+			k := new(big.Int).Mod(scalar, r)
+			want := (&G1Point{*new(curvePoint).ScalarMult(&p.p, k)}).Marshal()
+			got := new(G1Point).ScalarMultCT(p, scalar).Marshal()
+			if !bytes.Equal(got, want) {
+				t.Fatalf("expected: %x, got: %x", want, got)
+			}
+		})
+	}
+}
+
+func TestG1PointScalarMultFr(t *testing.T) {
+	p := new(G1Point).HashToPoint([]byte("abc"))
+	q := new(G1Point).HashToPoint([]byte("def"))
+	table := NewG1FixedBase(p)
+	b, _ := randInt(rand.Reader, r)
+	fb := new(Fr).SetInt(b)
+	for name, scalar := range scalarMultTests() {
+		t.Run(name, func(t *testing.T) {
+			k := new(Fr).SetInt(scalar)
+			tests := map[string]struct{ got, want *G1Point }{
+				"ScalarBaseMult":      {got: new(G1Point).ScalarBaseMultFr(k), want: new(G1Point).ScalarBaseMult(scalar)},
+				"ScalarMultFixedBase": {got: new(G1Point).ScalarMultFixedBaseFr(table, k), want: new(G1Point).ScalarMultFixedBase(table, scalar)},
+				"ScalarMult":          {got: new(G1Point).ScalarMultFr(p, k), want: new(G1Point).ScalarMult(p, scalar)},
+				"ScalarMultCT":        {got: new(G1Point).ScalarMultCTFr(p, k), want: new(G1Point).ScalarMultCT(p, scalar)},
+				"DoubleScalarMult":    {got: new(G1Point).DoubleScalarMultFr(k, p, fb, q), want: new(G1Point).DoubleScalarMult(scalar, p, b, q)},
+				"DoubleScalarMultCT":  {got: new(G1Point).DoubleScalarMultCTFr(k, p, fb, q), want: new(G1Point).DoubleScalarMultCT(scalar, p, b, q)},
+			}
+			for method, tc := range tests {
+				if got, want := tc.got.Marshal(), tc.want.Marshal(); !bytes.Equal(got, want) {
+					t.Fatalf("%s: expected: %x, got: %x", method, want, got)
+				}
+			}
+		})
+	}
+	t.Run("MultiExp", func(t *testing.T) {
+		points, scalars := g1MultiExpInputs(20)
+		ks := make([]*Fr, len(scalars))
+		for i, scalar := range scalars {
+			ks[i] = new(Fr).SetInt(scalar)
+		}
+		want, _ := G1MultiExp(points, scalars)
+		got, err := G1MultiExpFr(points, ks)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got.Marshal(), want.Marshal()) {
+			t.Fatalf("expected: %x, got: %x", want.Marshal(), got.Marshal())
+		}
+		if _, err := G1MultiExpFr(points, ks[1:]); err != ErrMismatchedLengths {
+			t.Fatalf("expected: %v, got: %v", ErrMismatchedLengths, err)
+		}
+	})
 }
 
 func TestG1PointAdd(t *testing.T) {
 	// TODO
 }
 
+// See https://www.rfc-editor.org/rfc/rfc9380.html#appendix-J.9.1.
+func TestG1PointHashToPointWithDomain(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-BLS12381G1_XMD:SHA-256_SSWU_RO_")
+	tests := map[string]struct {
+		msg  string
+		want curvePoint
+	}{
+		"empty message": {
+			msg: "",
+			want: newAffineCurvePoint(
+				"0x052926add2207b76ca4fa57a8734416c8dc95e24501772c814278700eed6d1e4e8cf62d9c09db0fac349612b759e79a1",
+				"0x08ba738453bfed09cb546dbb0783dbb3a5f1f566ed67bb6be0e8c67e2e81a4cc68ee29813bb7994998f3eae0c9c6a265",
+			),
+		},
+		"abc": {
+			msg: "abc",
+			want: newAffineCurvePoint(
+				"0x03567bc5ef9c690c2ab2ecdf6a96ef1c139cc0b2f284dca0a9a7943388a49a3aee664ba5379a7655d3c68900be2f6903",
+				"0x0b9c15f3fe6e5cf4211f346271d7b01c8f3b28be689c8429c85b67af215533311f0b8dfaaa154fa6b88176c229f2885d",
+			),
+		},
+		"abcdef0123456789": {
+			msg: "abcdef0123456789",
+			want: newAffineCurvePoint(
+				"0x11e0b079dea29a68f0383ee94fed1b940995272407e3bb916bbf268c263ddd57a6a27200a784cbc248e84f357ce82d98",
+				"0x03a87ae2caf14e8ee52e51fa2ed8eefe80f02457004ba4d486d6aa1f517c0889501dc7413753f9599b099ebcbbd2d709",
+			),
+		},
+		"q128": {
+			msg: msgQ128,
+			want: newAffineCurvePoint(
+				"0x15f68eaa693b95ccb85215dc65fa81038d69629f70aeee0d0f677cf22285e7bf58d7cb86eefe8f2e9bc3f8cb84fac488",
+				"0x1807a1d50c29f430b8cafc4f8638dfeeadf51211e1602a5f184443076715f91bb90a48ba1e370edce6ae1062f5e6dd38",
+			),
+		},
+		"a512": {
+			msg: msgA512,
+			want: newAffineCurvePoint(
+				"0x082aabae8b7dedb0e78aeb619ad3bfd9277a2f77ba7fad20ef6aabdc6c31d19ba5a6d12283553294c1825c4b3ca2dcfe",
+				"0x05b84ae5a942248eea39e1d91030458c40153f3b654ab7872d779ad1e942856a20c438e8d99bc8abfbf74729ce1f7ac8",
+			),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := new(G1Point).HashToPointWithDomain([]byte(tc.msg), dst).ToAffine()
+			if got.p != tc.want {
+				t.Fatalf("expected: %v, got: %v", tc.want, got.p)
+			}
+		})
+	}
+}
+
+// See https://www.rfc-editor.org/rfc/rfc9380.html#appendix-J.9.2.
+func TestG1PointEncodeToPointWithDomain(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-BLS12381G1_XMD:SHA-256_SSWU_NU_")
+	tests := map[string]struct {
+		msg  string
+		want curvePoint
+	}{
+		"empty message": {
+			msg: "",
+			want: newAffineCurvePoint(
+				"0x184bb665c37ff561a89ec2122dd343f20e0f4cbcaec84e3c3052ea81d1834e192c426074b02ed3dca4e7676ce4ce48ba",
+				"0x04407b8d35af4dacc809927071fc0405218f1401a6d15af775810e4e460064bcc9468beeba82fdc751be70476c888bf3",
+			),
+		},
+		"abc": {
+			msg: "abc",
+			want: newAffineCurvePoint(
+				"0x009769f3ab59bfd551d53a5f846b9984c59b97d6842b20a2c565baa167945e3d026a3755b6345df8ec7e6acb6868ae6d",
+				"0x1532c00cf61aa3d0ce3e5aa20c3b531a2abd2c770a790a2613818303c6b830ffc0ecf6c357af3317b9575c567f11cd2c",
+			),
+		},
+		"abcdef0123456789": {
+			msg: "abcdef0123456789",
+			want: newAffineCurvePoint(
+				"0x1974dbb8e6b5d20b84df7e625e2fbfecb2cdb5f77d5eae5fb2955e5ce7313cae8364bc2fff520a6c25619739c6bdcb6a",
+				"0x15f9897e11c6441eaa676de141c8d83c37aab8667173cbe1dfd6de74d11861b961dccebcd9d289ac633455dfcc7013a3",
+			),
+		},
+		"q128": {
+			msg: msgQ128,
+			want: newAffineCurvePoint(
+				"0x0a7a047c4a8397b3446450642c2ac64d7239b61872c9ae7a59707a8f4f950f101e766afe58223b3bff3a19a7f754027c",
+				"0x1383aebba1e4327ccff7cf9912bda0dbc77de048b71ef8c8a81111d71dc33c5e3aa6edee9cf6f5fe525d50cc50b77cc9",
+			),
+		},
+		"a512": {
+			msg: msgA512,
+			want: newAffineCurvePoint(
+				"0x0e7a16a975904f131682edbb03d9560d3e48214c9986bd50417a77108d13dc957500edf96462a3d01e62dc6cd468ef11",
+				"0x0ae89e677711d05c30a48d6d75e76ca9fb70fe06c6dd6ff988683d89ccde29ac7d46c53bb97a59b1901abf1db66052db",
+			),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := new(G1Point).EncodeToPointWithDomain([]byte(tc.msg), dst).ToAffine()
+			if got.p != tc.want {
+				t.Fatalf("expected: %v, got: %v", tc.want, got.p)
+			}
+		})
+	}
+}
+
+func TestG1PointEncodeToPoint(t *testing.T) {
+	msgs := map[string]string{
+		"empty message": "",
+		"abc":           "abc",
+		"q128":          msgQ128,
+	}
+	for name, msg := range msgs {
+		t.Run(name, func(t *testing.T) {
+			got := new(G1Point).EncodeToPoint([]byte(msg))
+			if !got.IsInSubgroup() {
+				t.Fatal("expected a point of the subgroup")
+			}
+			want := new(G1Point).EncodeToPointWithDomain([]byte(msg), g1EncodeDomain)
+			if !bytes.Equal(got.Marshal(), want.Marshal()) {
+				t.Fatalf("expected: %x, got: %x", want.Marshal(), got.Marshal())
+			}
+			if bytes.Equal(got.Marshal(), new(G1Point).HashToPoint([]byte(msg)).Marshal()) {
+				t.Fatal("expected the encoding to differ from the hash")
+			}
+		})
+	}
+}
+
+// g1MultiExpInputs returns n points, including the point at infinity, equal
+// and opposite points, and n random scalars.
+func g1MultiExpInputs(n int) ([]*G1Point, []*big.Int) {
+	points := make([]*G1Point, n)
+	scalars := make([]*big.Int, n)
+	for i := range points {
+		switch {
+		case i%7 == 3:
+			points[i] = new(G1Point)
+		case i%5 == 4:
+			points[i] = &G1Point{*new(curvePoint).Neg(&points[i-1].p)}
+		case i%11 == 10:
+			points[i] = points[i-2]
+		default:
+			points[i] = new(G1Point).HashToPoint([]byte(fmt.Sprintf("point %d", i)))
+		}
+		scalars[i], _ = randInt(rand.Reader, r)
+	}
+	return points, scalars
+}
+
+func TestG1MultiExp(t *testing.T) {
+	// 1300 inputs have more than multiExpBatchMinPoints finite points, so
+	// that the bucket additions are batched.
+	for _, n := range []int{0, 1, 2, 5, 64, 300, 1300} {
+		for _, workers := range []int{1, 4} {
+			t.Run(fmt.Sprintf("%d points, %d workers", n, workers), func(t *testing.T) {
+				points, scalars := g1MultiExpInputs(n)
+				want := new(G1Point)
+				for i := range points {
+					want.Add(want, new(G1Point).ScalarMult(points[i], scalars[i]))
+				}
+				got, err := G1MultiExpWithWorkers(points, scalars, workers)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(got.Marshal(), want.Marshal()) {
+					t.Fatalf("expected: %x, got: %x", want.Marshal(), got.Marshal())
+				}
+			})
+		}
+	}
+	for _, batching := range []bool{false, true} {
+		t.Run(fmt.Sprintf("equal buckets, batching %v", batching), func(t *testing.T) {
+			if batching {
+				defer func(n int) { multiExpBatchMinPoints = n }(multiExpBatchMinPoints)
+				multiExpBatchMinPoints = 0
+			}
+
+			// all the additions to the same buckets: a cancellation, a
+			// doubling, a batched addition and additions to the bucket while
+			// it is in the batch.
+			p := new(G1Point).HashToPoint([]byte("abc"))
+			minusP := &G1Point{*new(curvePoint).Neg(&p.p)}
+			points := []*G1Point{p, minusP, p, p, p, minusP, minusP}
+			scalars := make([]*big.Int, len(points))
+			for i := range scalars {
+				scalars[i] = big.NewInt(3)
+			}
+			got, err := G1MultiExp(points, scalars)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := new(G1Point).ScalarMult(p, big.NewInt(3))
+			if !bytes.Equal(got.Marshal(), want.Marshal()) {
+				t.Fatalf("expected: %x, got: %x", want.Marshal(), got.Marshal())
+			}
+		})
+	}
+	t.Run("mismatched lengths", func(t *testing.T) {
+		points, scalars := g1MultiExpInputs(3)
+		if _, err := G1MultiExp(points, scalars[1:]); err != ErrMismatchedLengths {
+			t.Fatalf("expected: %v, got: %v", ErrMismatchedLengths, err)
+		}
+	})
+}
+
 func TestG1PointSetBytes(t *testing.T) {
 	// TODO
 }
 
+func TestG1PointIsInSubgroup(t *testing.T) {
+	tests := map[string]struct {
+		point G1Point
+		want  bool
+	}{
+		"infinity":  {point: G1Point{}, want: true},
+		"generator": {point: *g1Gen, want: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := tc.point.IsInSubgroup()
+			if got != tc.want {
+				t.Fatalf("expected: %v, got: %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestG1PointIsInSubgroupRandom(t *testing.T) {
+	for i := 0; i < 16; i++ {
+		t.Run(fmt.Sprintf("point %d", i), func(t *testing.T) {
+			bigU, _ := randInt(rand.Reader, q)
+			u, _ := new(fq).SetInt(bigU)
+			p := new(curvePoint).MapToCurve(u)
+			for _, point := range []*G1Point{{*p}, {*new(curvePoint).ClearCofactor(p)}} {
+				want := new(curvePoint).ScalarMult(&point.p, r).IsInfinity()
+				got := point.IsInSubgroup()
+				if got != want {
+					t.Fatalf("expected: %v, got: %v", want, got)
+				}
+			}
+		})
+	}
+}
+
+// g1Uncompressed and g1Compressed are the Zcash encodings of multiples of the
+// generator, taken from the zkcrypto test vectors.
+var (
+	g1Uncompressed = map[string]struct {
+		scalar int64
+		data   string
+	}{
+		"infinity":      {scalar: 0, data: "400000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"},
+		"generator":     {scalar: 1, data: "17f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb08b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e1"},
+		"2*generator":   {scalar: 2, data: "0572cbea904d67468808c8eb50a9450c9721db309128012543902d0ac358a62ae28f75bb8f1c7c42c39a8c5529bf0f4e166a9d8cabc673a322fda673779d8e3822ba3ecb8670e461f73bb9021d5fd76a4c56d9d4cd16bd1bba86881979749d28"},
+		"3*generator":   {scalar: 3, data: "09ece308f9d1f0131765212deca99697b112d61f9be9a5f1f3780a51335b3ff981747a0b2ca2179b96d2c0c9024e5224032b80d3a6f5b09f8a84623389c5f80ca69a0cddabc3097f9d9c27310fd43be6e745256c634af45ca3473b0590ae30d1"},
+		"100*generator": {scalar: 100, data: "029e520a73ec28f4e2e45050c93080eeaee57af1108e659d740897c3ced76ceb75d106cb00d7ed25ec221874bf4b235a1266a1edf681bb73bd4f85e9b4a336f0079896b4e003ee19beb9cb7019a9d7c962daa06c242ab677447e7867d12a15ca"},
+		"999*generator": {scalar: 999, data: "194ba65546846b439edbfc9da84c1c2d2af3d0ede8c88ec50fce2e1c3f782e932205982683f0802a4dce313610bbb2db110cf0bbf7d06446072f32b6859704b28f9f8450acd4e766cb587769c3af2ee7cd3fa1589a9ae62fbff503fd953a78d6"},
+	}
+	g1Compressed = map[string]struct {
+		scalar int64
+		data   string
+	}{
+		"infinity":      {scalar: 0, data: "c00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"},
+		"generator":     {scalar: 1, data: "97f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb"},
+		"2*generator":   {scalar: 2, data: "a572cbea904d67468808c8eb50a9450c9721db309128012543902d0ac358a62ae28f75bb8f1c7c42c39a8c5529bf0f4e"},
+		"3*generator":   {scalar: 3, data: "89ece308f9d1f0131765212deca99697b112d61f9be9a5f1f3780a51335b3ff981747a0b2ca2179b96d2c0c9024e5224"},
+		"100*generator": {scalar: 100, data: "a29e520a73ec28f4e2e45050c93080eeaee57af1108e659d740897c3ced76ceb75d106cb00d7ed25ec221874bf4b235a"},
+		"999*generator": {scalar: 999, data: "b94ba65546846b439edbfc9da84c1c2d2af3d0ede8c88ec50fce2e1c3f782e932205982683f0802a4dce313610bbb2db"},
+	}
+)
+
 func TestG1PointMarshal(t *testing.T) {
-	// TODO
+	for name, tc := range g1Uncompressed {
+		t.Run(name, func(t *testing.T) {
+			got := hex.EncodeToString(new(G1Point).ScalarBaseMult(big.NewInt(tc.scalar)).Marshal())
+			if got != tc.data {
+				t.Fatalf("expected: %v, got: %v", tc.data, got)
+			}
+		})
+	}
+}
+
+func TestG1PointMarshalCompressed(t *testing.T) {
+	for name, tc := range g1Compressed {
+		t.Run(name, func(t *testing.T) {
+			got := hex.EncodeToString(new(G1Point).ScalarBaseMult(big.NewInt(tc.scalar)).MarshalCompressed())
+			if got != tc.data {
+				t.Fatalf("expected: %v, got: %v", tc.data, got)
+			}
+		})
+	}
 }
 
 func TestG1PointUnmarshal(t *testing.T) {
-	// TODO
+	for name, tc := range g1Uncompressed {
+		t.Run(name, func(t *testing.T) {
+			data, _ := hex.DecodeString(tc.data)
+			got := new(G1Point)
+			if err := got.Unmarshal(data); err != nil {
+				t.Fatal(err)
+			}
+			want := new(G1Point).ScalarBaseMult(big.NewInt(tc.scalar)).ToAffine()
+			if got.p != want.p {
+				t.Fatalf("expected: %v, got: %v", want.p, got.p)
+			}
+		})
+	}
+}
+
+func TestG1PointUnmarshalCompressed(t *testing.T) {
+	for name, tc := range g1Compressed {
+		t.Run(name, func(t *testing.T) {
+			data, _ := hex.DecodeString(tc.data)
+			got := new(G1Point)
+			if err := got.UnmarshalCompressed(data); err != nil {
+				t.Fatal(err)
+			}
+			want := new(G1Point).ScalarBaseMult(big.NewInt(tc.scalar)).ToAffine()
+			if got.p != want.p {
+				t.Fatalf("expected: %v, got: %v", want.p, got.p)
+			}
+		})
+	}
+}
+
+func TestG1PointUnmarshalInvalid(t *testing.T) {
+	tests := map[string]struct {
+		compressed bool
+		data       string
+		want       error
+	}{
+		"compressed, short":               {compressed: true, data: "97f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6", want: ErrInvalidLength},
+		"compressed, no compression flag": {compressed: true, data: "17f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb", want: ErrInvalidFlags},
+		"compressed, infinity with sign":  {compressed: true, data: "e00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000", want: ErrInvalidInfinity},
+		"compressed, infinity with x":     {compressed: true, data: "c00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000001", want: ErrInvalidInfinity},
+		"compressed, non-canonical x":     {compressed: true, data: "9a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab", want: ErrNonCanonical},
+		"compressed, not on curve":        {compressed: true, data: "800000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000001", want: ErrNotOnCurve},
+		"compressed, not in subgroup":     {compressed: true, data: "800000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000004", want: ErrNotInSubgroup},
+		"uncompressed, short":             {compressed: false, data: "17f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb08b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7", want: ErrInvalidLength},
+		"uncompressed, compression flag":  {compressed: false, data: "97f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb08b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e1", want: ErrInvalidFlags},
+		"uncompressed, sign flag":         {compressed: false, data: "37f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb08b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e1", want: ErrInvalidFlags},
+		"uncompressed, infinity with y":   {compressed: false, data: "400000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000001", want: ErrInvalidInfinity},
+		"uncompressed, non-canonical y":   {compressed: false, data: "17f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab", want: ErrNonCanonical},
+		"uncompressed, not on curve":      {compressed: false, data: "17f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb08b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e0", want: ErrNotOnCurve},
+		"uncompressed, not in subgroup":   {compressed: false, data: "0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000040a989badd40d6212b33cffc3f3763e9bc760f988c9926b26da9dd85e928483446346b8ed00e1de5d5ea93e354abe706c", want: ErrNotInSubgroup},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			data, _ := hex.DecodeString(tc.data)
+			z := &G1Point{g1Gen.p}
+			var err error
+			if tc.compressed {
+				err = z.UnmarshalCompressed(data)
+			} else {
+				err = z.Unmarshal(data)
+			}
+			if err != tc.want {
+				t.Fatalf("expected: %v, got: %v", tc.want, err)
+			}
+			if *z != *g1Gen {
+				t.Fatalf("expected z unchanged, got: %v", z)
+			}
+		})
+	}
 }
 
 func BenchmarkG1(b *testing.B) {
@@ -37,3 +549,57 @@ func BenchmarkG1(b *testing.B) {
 		new(G1Point).ScalarBaseMult(x)
 	}
 }
+
+func BenchmarkG1ScalarMult(b *testing.B) {
+	x, _ := RandFieldElement(rand.Reader)
+	p := new(G1Point).HashToPoint([]byte("abc"))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		new(G1Point).ScalarMult(p, x)
+	}
+}
+
+func BenchmarkG1ScalarMultCT(b *testing.B) {
+	x, _ := RandFieldElement(rand.Reader)
+	p := new(G1Point).HashToPoint([]byte("abc"))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		new(G1Point).ScalarMultCT(p, x)
+	}
+}
+
+func BenchmarkNewG1FixedBase(b *testing.B) {
+	p := new(G1Point).HashToPoint([]byte("abc"))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		NewG1FixedBase(p)
+	}
+}
+
+func BenchmarkG1MultiExp(b *testing.B) {
+	for _, n := range []int{16, 256, 4096} {
+		b.Run(fmt.Sprintf("%d points", n), func(b *testing.B) {
+			points, scalars := g1MultiExpInputs(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				G1MultiExp(points, scalars)
+			}
+		})
+	}
+}
+
+func BenchmarkG1DoubleScalarMult(b *testing.B) {
+	x, _ := RandFieldElement(rand.Reader)
+	y, _ := RandFieldElement(rand.Reader)
+	p := new(G1Point).HashToPoint([]byte("abc"))
+	q := new(G1Point).HashToPoint([]byte("def"))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		new(G1Point).DoubleScalarMult(x, p, y, q)
+	}
+}
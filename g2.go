@@ -2,6 +2,7 @@ package bls12
 
 import (
 	"math/big"
+	"sync"
 )
 
 var g2Gen = &G2Point{*newTwistPoint(fq2{*g2X0, *g2X1}, fq2{*g2Y0, *g2Y1})}
@@ -23,21 +24,192 @@ func (x *G2Point) Equal(y *G2Point) bool {
 	return x.p == y.p
 }
 
+// G2MultiExp returns the sum of scalars[i]*points[i], where the scalars are
+// numbers in big-endian form. The points must be in G2. G2MultiExp is much
+// faster than separate scalar multiplications for many points and uses as many
+// goroutines as GOMAXPROCS.
+func G2MultiExp(points []*G2Point, scalars []*big.Int) (*G2Point, error) {
+	return G2MultiExpWithWorkers(points, scalars, multiExpWorkers())
+}
+
+// G2MultiExpWithWorkers is like G2MultiExp but spreads the work across the
+// given number of goroutines.
+func G2MultiExpWithWorkers(points []*G2Point, scalars []*big.Int, workers int) (*G2Point, error) {
+	if len(points) != len(scalars) {
+		return nil, ErrMismatchedLengths
+	}
+
+	ks := make([][scalarWords]uint64, len(scalars))
+	for i, scalar := range scalars {
+		ks[i] = ctScalar(scalar)
+	}
+	return g2MultiExp(points, ks, workers), nil
+}
+
+// G2MultiExpFr is like G2MultiExp with scalars of Fr.
+func G2MultiExpFr(points []*G2Point, scalars []*Fr) (*G2Point, error) {
+	return G2MultiExpFrWithWorkers(points, scalars, multiExpWorkers())
+}
+
+// G2MultiExpFrWithWorkers is like G2MultiExpWithWorkers with scalars of Fr.
+func G2MultiExpFrWithWorkers(points []*G2Point, scalars []*Fr, workers int) (*G2Point, error) {
+	if len(points) != len(scalars) {
+		return nil, ErrMismatchedLengths
+	}
+
+	ks := make([][scalarWords]uint64, len(scalars))
+	for i, scalar := range scalars {
+		ks[i] = scalar.words()
+	}
+	return g2MultiExp(points, ks, workers), nil
+}
+
+// g2MultiExp returns the sum of ks[i]*points[i], where the scalars are
+// reduced modulo r.
+func g2MultiExp(points []*G2Point, ks [][scalarWords]uint64, workers int) *G2Point {
+	ps := make([]*twistPoint, len(points))
+	for i, point := range points {
+		ps[i] = &point.p
+	}
+
+	z := new(G2Point)
+	z.p.MultiExp(ps, ks, workers)
+	return z
+}
+
 // Add sets z to the sum x+y and returns z.
 func (z *G2Point) Add(x, y *G2Point) *G2Point {
 	z.p.Add(&x.p, &y.p)
 	return z
 }
 
+// Neg returns -x.
+func (z *G2Point) Neg(x *G2Point) *G2Point {
+	z.p.Neg(&x.p)
+	return z
+}
+
 // ScalarBaseMult returns k*G, where G is the base point of the group
-// and k is an integer in big-endian form.
+// and k is an integer in big-endian form. The multiplication runs in constant
+// time with respect to k, but the conversion of the big.Int leaks the length of
+// k: secret scalars should be given to ScalarBaseMultFr.
 func (z *G2Point) ScalarBaseMult(scalar *big.Int) *G2Point {
-	return z.ScalarMult(g2Gen, scalar)
+	return z.ScalarMultFixedBase(g2GenFixedBase(), scalar)
+}
+
+// ScalarBaseMultFr is like ScalarBaseMult with a scalar of Fr.
+func (z *G2Point) ScalarBaseMultFr(scalar *Fr) *G2Point {
+	return z.ScalarMultFixedBaseFr(g2GenFixedBase(), scalar)
+}
+
+// G2FixedBase holds precomputed multiples of a fixed point of G2, such as
+// a commitment generator, to speed up its scalar multiplications.
+type G2FixedBase struct {
+	t twistFixedBase
+}
+
+var (
+	g2GenTable     *G2FixedBase
+	g2GenTableOnce sync.Once
+)
+
+// g2GenFixedBase returns the table of the generator, which is built on the
+// first use.
+func g2GenFixedBase() *G2FixedBase {
+	g2GenTableOnce.Do(func() {
+		g2GenTable = NewG2FixedBase(g2Gen)
+	})
+	return g2GenTable
+}
+
+// NewG2FixedBase returns the table of x. x must be in G2. Building the table
+// costs about as much as ten scalar multiplications, so it is only worth it for
+// points that are multiplied many times.
+func NewG2FixedBase(x *G2Point) *G2FixedBase {
+	b := new(G2FixedBase)
+	b.t.Init(&x.p)
+	return b
+}
+
+// ScalarMultFixedBase returns k*(Bx,By), where (Bx,By) is the point of the table
+// b and k is a number in big-endian form. The multiplication runs in constant
+// time with respect to k, but the conversion of the big.Int leaks the length of
+// k: secret scalars should be given to ScalarMultFixedBaseFr.
+func (z *G2Point) ScalarMultFixedBase(b *G2FixedBase, scalar *big.Int) *G2Point {
+	k := ctScalar(scalar)
+	z.p.ScalarMultFixedBase(&b.t, &k)
+	return z
+}
+
+// ScalarMultFixedBaseFr is like ScalarMultFixedBase with a scalar of Fr.
+func (z *G2Point) ScalarMultFixedBaseFr(b *G2FixedBase, scalar *Fr) *G2Point {
+	k := scalar.words()
+	z.p.ScalarMultFixedBase(&b.t, &k)
+	return z
 }
 
-// ScalarMult returns k*(Bx,By) where k is a number in big-endian form.
+// ScalarMult returns k*(Bx,By) where k is a number in big-endian form. x must
+// be in G2.
 func (z *G2Point) ScalarMult(x *G2Point, scalar *big.Int) *G2Point {
-	z.p.ScalarMult(&x.p, scalar)
+	k := ctScalar(scalar)
+	z.p.ScalarMultGLS(&x.p, &k)
+	return z
+}
+
+// ScalarMultFr is like ScalarMult with a scalar of Fr.
+func (z *G2Point) ScalarMultFr(x *G2Point, scalar *Fr) *G2Point {
+	k := scalar.words()
+	z.p.ScalarMultGLS(&x.p, &k)
+	return z
+}
+
+// ScalarMultCT returns k*(Bx,By) where k is a number in big-endian form. The
+// multiplication runs in constant time with respect to k, but the conversion of
+// the big.Int leaks the length of k: secret scalars should be given to
+// ScalarMultCTFr. x must be in G2.
+func (z *G2Point) ScalarMultCT(x *G2Point, scalar *big.Int) *G2Point {
+	k := ctScalar(scalar)
+	z.p.ScalarMultCT(&x.p, &k)
+	return z
+}
+
+// ScalarMultCTFr is like ScalarMultCT with a scalar of Fr.
+func (z *G2Point) ScalarMultCTFr(x *G2Point, scalar *Fr) *G2Point {
+	k := scalar.words()
+	z.p.ScalarMultCT(&x.p, &k)
+	return z
+}
+
+// DoubleScalarMult returns a*p + b*q, where a and b are numbers in big-endian
+// form, faster than two separate scalar multiplications. p and q must be in
+// G2.
+func (z *G2Point) DoubleScalarMult(a *big.Int, p *G2Point, b *big.Int, q *G2Point) *G2Point {
+	ka, kb := ctScalar(a), ctScalar(b)
+	z.p.DoubleScalarMultGLS(&ka, &p.p, &kb, &q.p)
+	return z
+}
+
+// DoubleScalarMultFr is like DoubleScalarMult with scalars of Fr.
+func (z *G2Point) DoubleScalarMultFr(a *Fr, p *G2Point, b *Fr, q *G2Point) *G2Point {
+	ka, kb := a.words(), b.words()
+	z.p.DoubleScalarMultGLS(&ka, &p.p, &kb, &q.p)
+	return z
+}
+
+// DoubleScalarMultCT returns a*p + b*q, where a and b are numbers in
+// big-endian form. The multiplication runs in constant time with respect to a
+// and b, but the conversion of the big.Ints leaks their lengths: secret scalars
+// should be given to DoubleScalarMultCTFr. p and q must be in G2.
+func (z *G2Point) DoubleScalarMultCT(a *big.Int, p *G2Point, b *big.Int, q *G2Point) *G2Point {
+	ka, kb := ctScalar(a), ctScalar(b)
+	z.p.DoubleScalarMultCT(&ka, &p.p, &kb, &q.p)
+	return z
+}
+
+// DoubleScalarMultCTFr is like DoubleScalarMultCT with scalars of Fr.
+func (z *G2Point) DoubleScalarMultCTFr(a *Fr, p *G2Point, b *Fr, q *G2Point) *G2Point {
+	ka, kb := a.words(), b.words()
+	z.p.DoubleScalarMultCT(&ka, &p.p, &kb, &q.p)
 	return z
 }
 
@@ -46,14 +218,83 @@ func (z *G2Point) ToAffine() *G2Point {
 	return z
 }
 
+// HashToPoint hashes buf to a point of the group using the default domain
+// separation tag. The point is guaranteed to be in the subgroup.
 func (z *G2Point) HashToPoint(buf []byte) *G2Point {
-	// TODO review
-	return z.HashToPointWithDomain(buf, 0)
+	return z.HashToPointWithDomain(buf, g2Domain)
+}
+
+// HashToPointWithDomain hashes buf to a point of the group using the domain
+// separation tag dst. The point is guaranteed to be in the subgroup. The
+// cofactor is cleared with h_eff, as in RFC 9380, and not with the cofactor h
+// used by earlier versions of this package, so the hashes differ from theirs.
+func (z *G2Point) HashToPointWithDomain(buf, dst []byte) *G2Point {
+	z.p.HashToPoint(buf, dst)
+	z.p.ClearCofactor(&z.p)
+	return z
+}
+
+// EncodeToPoint encodes buf to a point of the group using the default domain
+// separation tag. The point is guaranteed to be in the subgroup. The encoding
+// is cheaper than HashToPoint but its output is not uniformly distributed.
+func (z *G2Point) EncodeToPoint(buf []byte) *G2Point {
+	return z.EncodeToPointWithDomain(buf, g2EncodeDomain)
+}
+
+// EncodeToPointWithDomain encodes buf to a point of the group using the domain
+// separation tag dst. The point is guaranteed to be in the subgroup.
+func (z *G2Point) EncodeToPointWithDomain(buf, dst []byte) *G2Point {
+	z.p.EncodeToPoint(buf, dst)
+	z.p.ClearCofactor(&z.p)
+	return z
+}
+
+// IsInSubgroup reports whether z is in the subgroup of order r. Points
+// obtained through Unmarshal or hashing are always in the subgroup.
+func (z *G2Point) IsInSubgroup() bool {
+	return z.p.IsInSubgroup()
+}
+
+// Marshal converts z into the 192-byte uncompressed form, compatible with the
+// Zcash serialization of BLS12-381 points.
+func (z *G2Point) Marshal() []byte {
+	return z.p.Marshal()
 }
 
-// HashToPointWithDomain uses the Shallue and van de Woestijne encoding.
-// The point is guaranteed to be in the subgroup.
-func (z *G2Point) HashToPointWithDomain(buf []byte, domain uint64) *G2Point {
-	//z.p.HashToPoint(buf, g10, g11)
-	return z.ScalarMult(z, g2Cofactor)
+// MarshalCompressed converts z into the 96-byte compressed form, compatible
+// with the Zcash serialization of BLS12-381 points.
+func (z *G2Point) MarshalCompressed() []byte {
+	return z.p.MarshalCompressed()
+}
+
+// Unmarshal sets z to the result of converting the output of Marshal back into
+// a group element. The encoding must be canonical and the point must be in the
+// subgroup. On error, z is left unchanged.
+func (z *G2Point) Unmarshal(data []byte) error {
+	p := new(twistPoint)
+	if err := p.Unmarshal(data); err != nil {
+		return err
+	}
+	if !p.IsInSubgroup() {
+		return ErrNotInSubgroup
+	}
+	z.p.Set(p)
+
+	return nil
+}
+
+// UnmarshalCompressed sets z to the result of converting the output of
+// MarshalCompressed back into a group element. The encoding must be canonical
+// and the point must be in the subgroup. On error, z is left unchanged.
+func (z *G2Point) UnmarshalCompressed(data []byte) error {
+	p := new(twistPoint)
+	if err := p.UnmarshalCompressed(data); err != nil {
+		return err
+	}
+	if !p.IsInSubgroup() {
+		return ErrNotInSubgroup
+	}
+	z.p.Set(p)
+
+	return nil
 }
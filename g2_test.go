@@ -1,7 +1,11 @@
 package bls12
 
 import (
+	"bytes"
 	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math/big"
 	"testing"
 )
 
@@ -13,22 +17,539 @@ func TestG2PointEqual(t *testing.T) {
 	// TODO
 }
 
+func TestG2PointScalarMultFr(t *testing.T) {
+	p := new(G2Point).HashToPoint([]byte("abc"))
+	q := new(G2Point).HashToPoint([]byte("def"))
+	table := NewG2FixedBase(p)
+	b, _ := randInt(rand.Reader, r)
+	fb := new(Fr).SetInt(b)
+	for name, scalar := range scalarMultTests() {
+		t.Run(name, func(t *testing.T) {
+			k := new(Fr).SetInt(scalar)
+			tests := map[string]struct{ got, want *G2Point }{
+				"ScalarBaseMult":      {got: new(G2Point).ScalarBaseMultFr(k), want: new(G2Point).ScalarBaseMult(scalar)},
+				"ScalarMultFixedBase": {got: new(G2Point).ScalarMultFixedBaseFr(table, k), want: new(G2Point).ScalarMultFixedBase(table, scalar)},
+				"ScalarMult":          {got: new(G2Point).ScalarMultFr(p, k), want: new(G2Point).ScalarMult(p, scalar)},
+				"ScalarMultCT":        {got: new(G2Point).ScalarMultCTFr(p, k), want: new(G2Point).ScalarMultCT(p, scalar)},
+				"DoubleScalarMult":    {got: new(G2Point).DoubleScalarMultFr(k, p, fb, q), want: new(G2Point).DoubleScalarMult(scalar, p, b, q)},
+				"DoubleScalarMultCT":  {got: new(G2Point).DoubleScalarMultCTFr(k, p, fb, q), want: new(G2Point).DoubleScalarMultCT(scalar, p, b, q)},
+			}
+			for method, tc := range tests {
+				if got, want := tc.got.Marshal(), tc.want.Marshal(); !bytes.Equal(got, want) {
+					t.Fatalf("%s: expected: %x, got: %x", method, want, got)
+				}
+			}
+		})
+	}
+	t.Run("MultiExp", func(t *testing.T) {
+		points, scalars := g2MultiExpInputs(20)
+		ks := make([]*Fr, len(scalars))
+		for i, scalar := range scalars {
+			ks[i] = new(Fr).SetInt(scalar)
+		}
+		want, _ := G2MultiExp(points, scalars)
+		got, err := G2MultiExpFr(points, ks)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got.Marshal(), want.Marshal()) {
+			t.Fatalf("expected: %x, got: %x", want.Marshal(), got.Marshal())
+		}
+		if _, err := G2MultiExpFr(points, ks[1:]); err != ErrMismatchedLengths {
+			t.Fatalf("expected: %v, got: %v", ErrMismatchedLengths, err)
+		}
+	})
+}
+
 func TestG2PointAdd(t *testing.T) {
 	// TODO
 }
 
 func TestG2PointBaseScalarMult(t *testing.T) {
-	// TODO
+	for name, tc := range g2Uncompressed {
+		t.Run(name, func(t *testing.T) {
+			want, _ := hex.DecodeString(tc.data)
+			got := new(G2Point).ScalarBaseMult(big.NewInt(tc.scalar)).Marshal()
+			if !bytes.Equal(got, want) {
+				t.Fatalf("expected: %x, got: %x", want, got)
+			}
+		})
+	}
 }
 
 func TestG2PointScalarMult(t *testing.T) {
-	// TODO
+	p := new(G2Point).HashToPoint([]byte("abc"))
+	for name, scalar := range scalarMultTests() {
+		t.Run(name, func(t *testing.T) {
+			k := new(big.Int).Mod(scalar, r)
+			want := (&G2Point{*new(twistPoint).ScalarMult(&p.p, k)}).Marshal()
+			got := new(G2Point).ScalarMult(p, scalar).Marshal()
+			if !bytes.Equal(got, want) {
+				t.Fatalf("expected: %x, got: %x", want, got)
+			}
+		})
+	}
+}
+
+func TestG2PointDoubleScalarMult(t *testing.T) {
+	p := new(G2Point).HashToPoint([]byte("abc"))
+	q := new(G2Point).HashToPoint([]byte("def"))
+	scalars := scalarMultTests()
+	for name, a := range scalars {
+		b := scalars["random 0"]
+		if name == "random 0" {
+			b = scalars["r - 1"]
+		}
+		t.Run(name, func(t *testing.T) {
+			pa := new(twistPoint).ScalarMult(&p.p, new(big.Int).Mod(a, r))
+			qb := new(twistPoint).ScalarMult(&q.p, new(big.Int).Mod(b, r))
+			want := (&G2Point{*pa.Add(pa, qb)}).Marshal()
+			if got := new(G2Point).DoubleScalarMult(a, p, b, q).Marshal(); !bytes.Equal(got, want) {
+				t.Fatalf("expected: %x, got: %x", want, got)
+			}
+			if got := new(G2Point).DoubleScalarMultCT(a, p, b, q).Marshal(); !bytes.Equal(got, want) {
+				t.Fatalf("CT: expected: %x, got: %x", want, got)
+			}
+		})
+	}
+	t.Run("same point", func(t *testing.T) {
+		// a*p + (r-a)*p = 0
+		a := scalars["random 1"]
+		b := new(big.Int).Sub(r, a)
+		if got := new(G2Point).DoubleScalarMult(a, p, b, p); !got.p.IsInfinity() {
+			t.Fatalf("expected: infinity, got: %v", got)
+		}
+		if got := new(G2Point).DoubleScalarMultCT(a, p, b, p); !got.p.IsInfinity() {
+			t.Fatalf("CT: expected: infinity, got: %v", got)
+		}
+	})
+}
+
+func TestG2PointScalarMultFixedBase(t *testing.T) {
+	p := new(G2Point).HashToPoint([]byte("abc"))
+	table := NewG2FixedBase(p)
+	for name, scalar := range scalarMultTests() {
+		t.Run(name, func(t *testing.T) {
+			k := new(big.Int).Mod(scalar, r)
+			want := (&G2Point{*new(twistPoint).ScalarMult(&p.p, k)}).Marshal()
+			got := new(G2Point).ScalarMultFixedBase(table, scalar).Marshal()
+			if !bytes.Equal(got, want) {
+				t.Fatalf("expected: %x, got: %x", want, got)
+			}
+		})
+	}
+	t.Run("infinity", func(t *testing.T) {
+		got := new(G2Point).ScalarMultFixedBase(NewG2FixedBase(new(G2Point)), big.NewInt(5))
+		if !got.p.IsInfinity() {
+			t.Fatalf("expected: infinity, got: %v", got)
+		}
+	})
+}
+
+func TestG2PointScalarMultCT(t *testing.T) {
+	p := new(G2Point).HashToPoint([]byte("abc"))
+	for name, scalar := range scalarMultTests() {
+		t.Run(name, func(t *testing.T) {
+			k := new(big.Int).Mod(scalar, r)
+			want := (&G2Point{*new(twistPoint).ScalarMult(&p.p, k)}).Marshal()
+			got := new(G2Point).ScalarMultCT(p, scalar).Marshal()
+			if !bytes.Equal(got, want) {
+				t.Fatalf("expected: %x, got: %x", want, got)
+			}
+		})
+	}
 }
 
 func TestG2PointToAffine(t *testing.T) {
 	// TODO
 }
 
+// See https://www.rfc-editor.org/rfc/rfc9380.html#appendix-J.10.1.
+func TestG2PointHashToPointWithDomain(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-BLS12381G2_XMD:SHA-256_SSWU_RO_")
+	tests := map[string]struct {
+		msg  string
+		want twistPoint
+	}{
+		"empty message": {
+			msg: "",
+			want: newAffineTwistPoint(
+				"0x0141ebfbdca40eb85b87142e130ab689c673cf60f1a3e98d69335266f30d9b8d4ac44c1038e9dcdd5393faf5c41fb78a",
+				"0x05cb8437535e20ecffaef7752baddf98034139c38452458baeefab379ba13dff5bf5dd71b72418717047f5b0f37da03d",
+				"0x0503921d7f6a12805e72940b963c0cf3471c7b2a524950ca195d11062ee75ec076daf2d4bc358c4b190c0c98064fdd92",
+				"0x12424ac32561493f3fe3c260708a12b7c620e7be00099a974e259ddc7d1f6395c3c811cdd19f1e8dbf3e9ecfdcbab8d6",
+			),
+		},
+		"abc": {
+			msg: "abc",
+			want: newAffineTwistPoint(
+				"0x02c2d18e033b960562aae3cab37a27ce00d80ccd5ba4b7fe0e7a210245129dbec7780ccc7954725f4168aff2787776e6",
+				"0x139cddbccdc5e91b9623efd38c49f81a6f83f175e80b06fc374de9eb4b41dfe4ca3a230ed250fbe3a2acf73a41177fd8",
+				"0x1787327b68159716a37440985269cf584bcb1e621d3a7202be6ea05c4cfe244aeb197642555a0645fb87bf7466b2ba48",
+				"0x00aa65dae3c8d732d10ecd2c50f8a1baf3001578f71c694e03866e9f3d49ac1e1ce70dd94a733534f106d4cec0eddd16",
+			),
+		},
+		"abcdef0123456789": {
+			msg: "abcdef0123456789",
+			want: newAffineTwistPoint(
+				"0x121982811d2491fde9ba7ed31ef9ca474f0e1501297f68c298e9f4c0028add35aea8bb83d53c08cfc007c1e005723cd0",
+				"0x190d119345b94fbd15497bcba94ecf7db2cbfd1e1fe7da034d26cbba169fb3968288b3fafb265f9ebd380512a71c3f2c",
+				"0x05571a0f8d3c08d094576981f4a3b8eda0a8e771fcdcc8ecceaf1356a6acf17574518acb506e435b639353c2e14827c8",
+				"0x0bb5e7572275c567462d91807de765611490205a941a5a6af3b1691bfe596c31225d3aabdf15faff860cb4ef17c7c3be",
+			),
+		},
+		"q128": {
+			msg: msgQ128,
+			want: newAffineTwistPoint(
+				"0x19a84dd7248a1066f737cc34502ee5555bd3c19f2ecdb3c7d9e24dc65d4e25e50d83f0f77105e955d78f4762d33c17da",
+				"0x0934aba516a52d8ae479939a91998299c76d39cc0c035cd18813bec433f587e2d7a4fef038260eef0cef4d02aae3eb91",
+				"0x14f81cd421617428bc3b9fe25afbb751d934a00493524bc4e065635b0555084dd54679df1536101b2c979c0152d09192",
+				"0x09bcccfa036b4847c9950780733633f13619994394c23ff0b32fa6b795844f4a0673e20282d07bc69641cee04f5e5662",
+			),
+		},
+		"a512": {
+			msg: msgA512,
+			want: newAffineTwistPoint(
+				"0x01a6ba2f9a11fa5598b2d8ace0fbe0a0eacb65deceb476fbbcb64fd24557c2f4b18ecfc5663e54ae16a84f5ab7f62534",
+				"0x11fca2ff525572795a801eed17eb12785887c7b63fb77a42be46ce4a34131d71f7a73e95fee3f812aea3de78b4d01569",
+				"0x0b6798718c8aed24bc19cb27f866f1c9effcdbf92397ad6448b5c9db90d2b9da6cbabf48adc1adf59a1a28344e79d57e",
+				"0x03a47f8e6d1763ba0cad63d6114c0accbef65707825a511b251a660a9b3994249ae4e63fac38b23da0c398689ee2ab52",
+			),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := new(G2Point).HashToPointWithDomain([]byte(tc.msg), dst).ToAffine()
+			if got.p != tc.want {
+				t.Fatalf("expected: %v, got: %v", tc.want, got.p)
+			}
+		})
+	}
+}
+
+// See https://www.rfc-editor.org/rfc/rfc9380.html#appendix-J.10.2.
+func TestG2PointEncodeToPointWithDomain(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-BLS12381G2_XMD:SHA-256_SSWU_NU_")
+	tests := map[string]struct {
+		msg  string
+		want twistPoint
+	}{
+		"empty message": {
+			msg: "",
+			want: newAffineTwistPoint(
+				"0x00e7f4568a82b4b7dc1f14c6aaa055edf51502319c723c4dc2688c7fe5944c213f510328082396515734b6612c4e7bb7",
+				"0x126b855e9e69b1f691f816e48ac6977664d24d99f8724868a184186469ddfd4617367e94527d4b74fc86413483afb35b",
+				"0x0caead0fd7b6176c01436833c79d305c78be307da5f6af6c133c47311def6ff1e0babf57a0fb5539fce7ee12407b0a42",
+				"0x1498aadcf7ae2b345243e281ae076df6de84455d766ab6fcdaad71fab60abb2e8b980a440043cd305db09d283c895e3d",
+			),
+		},
+		"abc": {
+			msg: "abc",
+			want: newAffineTwistPoint(
+				"0x108ed59fd9fae381abfd1d6bce2fd2fa220990f0f837fa30e0f27914ed6e1454db0d1ee957b219f61da6ff8be0d6441f",
+				"0x0296238ea82c6d4adb3c838ee3cb2346049c90b96d602d7bb1b469b905c9228be25c627bffee872def773d5b2a2eb57d",
+				"0x033f90f6057aadacae7963b0a0b379dd46750c1c94a6357c99b65f63b79e321ff50fe3053330911c56b6ceea08fee656",
+				"0x153606c417e59fb331b7ae6bce4fbf7c5190c33ce9402b5ebe2b70e44fca614f3f1382a3625ed5493843d0b0a652fc3f",
+			),
+		},
+		"abcdef0123456789": {
+			msg: "abcdef0123456789",
+			want: newAffineTwistPoint(
+				"0x038af300ef34c7759a6caaa4e69363cafeed218a1f207e93b2c70d91a1263d375d6730bd6b6509dcac3ba5b567e85bf3",
+				"0x0da75be60fb6aa0e9e3143e40c42796edf15685cafe0279afd2a67c3dff1c82341f17effd402e4f1af240ea90f4b659b",
+				"0x19b148cbdf163cf0894f29660d2e7bfb2b68e37d54cc83fd4e6e62c020eaa48709302ef8e746736c0e19342cc1ce3df4",
+				"0x0492f4fed741b073e5a82580f7c663f9b79e036b70ab3e51162359cec4e77c78086fe879b65ca7a47d34374c8315ac5e",
+			),
+		},
+		"q128": {
+			msg: msgQ128,
+			want: newAffineTwistPoint(
+				"0x0c5ae723be00e6c3f0efe184fdc0702b64588fe77dda152ab13099a3bacd3876767fa7bbad6d6fd90b3642e902b208f9",
+				"0x12c8c05c1d5fc7bfa847f4d7d81e294e66b9a78bc9953990c358945e1f042eedafce608b67fdd3ab0cb2e6e263b9b1ad",
+				"0x04e77ddb3ede41b5ec4396b7421dd916efc68a358a0d7425bddd253547f2fb4830522358491827265dfc5bcc1928a569",
+				"0x11c624c56dbe154d759d021eec60fab3d8b852395a89de497e48504366feedd4662d023af447d66926a28076813dd646",
+			),
+		},
+		"a512": {
+			msg: msgA512,
+			want: newAffineTwistPoint(
+				"0x0ea4e7c33d43e17cc516a72f76437c4bf81d8f4eac69ac355d3bf9b71b8138d55dc10fd458be115afa798b55dac34be1",
+				"0x1565c2f625032d232f13121d3cfb476f45275c303a037faa255f9da62000c2c864ea881e2bcddd111edc4a3c0da3e88d",
+				"0x043b6f5fe4e52c839148dc66f2b3751e69a0f6ebb3d056d6465d50d4108543ecd956e10fa1640dfd9bc0030cc2558d28",
+				"0x0f8991d2a1ad662e7b6f58ab787947f1fa607fce12dde171bc17903b012091b657e15333e11701edcf5b63ba2a561247",
+			),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := new(G2Point).EncodeToPointWithDomain([]byte(tc.msg), dst).ToAffine()
+			if got.p != tc.want {
+				t.Fatalf("expected: %v, got: %v", tc.want, got.p)
+			}
+		})
+	}
+}
+
+func TestG2PointEncodeToPoint(t *testing.T) {
+	msgs := map[string]string{
+		"empty message": "",
+		"abc":           "abc",
+		"q128":          msgQ128,
+	}
+	for name, msg := range msgs {
+		t.Run(name, func(t *testing.T) {
+			got := new(G2Point).EncodeToPoint([]byte(msg))
+			if !got.IsInSubgroup() {
+				t.Fatal("expected a point of the subgroup")
+			}
+			want := new(G2Point).EncodeToPointWithDomain([]byte(msg), g2EncodeDomain)
+			if !bytes.Equal(got.Marshal(), want.Marshal()) {
+				t.Fatalf("expected: %x, got: %x", want.Marshal(), got.Marshal())
+			}
+			if bytes.Equal(got.Marshal(), new(G2Point).HashToPoint([]byte(msg)).Marshal()) {
+				t.Fatal("expected the encoding to differ from the hash")
+			}
+		})
+	}
+}
+
+// g2MultiExpInputs returns n points, including the point at infinity, equal
+// and opposite points, and n random scalars.
+func g2MultiExpInputs(n int) ([]*G2Point, []*big.Int) {
+	points := make([]*G2Point, n)
+	scalars := make([]*big.Int, n)
+	for i := range points {
+		switch {
+		case i%7 == 3:
+			points[i] = new(G2Point)
+		case i%5 == 4:
+			points[i] = &G2Point{*new(twistPoint).Neg(&points[i-1].p)}
+		case i%11 == 10:
+			points[i] = points[i-2]
+		default:
+			points[i] = new(G2Point).HashToPoint([]byte(fmt.Sprintf("point %d", i)))
+		}
+		scalars[i], _ = randInt(rand.Reader, r)
+	}
+	return points, scalars
+}
+
+func TestG2MultiExp(t *testing.T) {
+	// 1300 inputs have more than multiExpBatchMinPoints finite points, so
+	// that the bucket additions are batched.
+	for _, n := range []int{0, 1, 2, 5, 64, 300, 1300} {
+		for _, workers := range []int{1, 4} {
+			t.Run(fmt.Sprintf("%d points, %d workers", n, workers), func(t *testing.T) {
+				points, scalars := g2MultiExpInputs(n)
+				want := new(G2Point)
+				for i := range points {
+					want.Add(want, new(G2Point).ScalarMult(points[i], scalars[i]))
+				}
+				got, err := G2MultiExpWithWorkers(points, scalars, workers)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(got.Marshal(), want.Marshal()) {
+					t.Fatalf("expected: %x, got: %x", want.Marshal(), got.Marshal())
+				}
+			})
+		}
+	}
+	for _, batching := range []bool{false, true} {
+		t.Run(fmt.Sprintf("equal buckets, batching %v", batching), func(t *testing.T) {
+			if batching {
+				defer func(n int) { multiExpBatchMinPoints = n }(multiExpBatchMinPoints)
+				multiExpBatchMinPoints = 0
+			}
+
+			// all the additions to the same buckets: a cancellation, a
+			// doubling, a batched addition and additions to the bucket while
+			// it is in the batch.
+			p := new(G2Point).HashToPoint([]byte("abc"))
+			minusP := &G2Point{*new(twistPoint).Neg(&p.p)}
+			points := []*G2Point{p, minusP, p, p, p, minusP, minusP}
+			scalars := make([]*big.Int, len(points))
+			for i := range scalars {
+				scalars[i] = big.NewInt(3)
+			}
+			got, err := G2MultiExp(points, scalars)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := new(G2Point).ScalarMult(p, big.NewInt(3))
+			if !bytes.Equal(got.Marshal(), want.Marshal()) {
+				t.Fatalf("expected: %x, got: %x", want.Marshal(), got.Marshal())
+			}
+		})
+	}
+	t.Run("mismatched lengths", func(t *testing.T) {
+		points, scalars := g2MultiExpInputs(3)
+		if _, err := G2MultiExp(points, scalars[1:]); err != ErrMismatchedLengths {
+			t.Fatalf("expected: %v, got: %v", ErrMismatchedLengths, err)
+		}
+	})
+}
+
+func TestG2PointIsInSubgroup(t *testing.T) {
+	tests := map[string]struct {
+		point G2Point
+		want  bool
+	}{
+		"infinity":  {point: G2Point{}, want: true},
+		"generator": {point: *g2Gen, want: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := tc.point.IsInSubgroup()
+			if got != tc.want {
+				t.Fatalf("expected: %v, got: %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestG2PointIsInSubgroupRandom(t *testing.T) {
+	for i := 0; i < 16; i++ {
+		t.Run(fmt.Sprintf("point %d", i), func(t *testing.T) {
+			bigU0, _ := randInt(rand.Reader, q)
+			bigU1, _ := randInt(rand.Reader, q)
+			u := new(fq2)
+			u.c0.SetInt(bigU0)
+			u.c1.SetInt(bigU1)
+			p := new(twistPoint).MapToCurve(u)
+			for _, point := range []*G2Point{{*p}, {*new(twistPoint).ClearCofactor(p)}} {
+				want := new(twistPoint).ScalarMult(&point.p, r).IsInfinity()
+				got := point.IsInSubgroup()
+				if got != want {
+					t.Fatalf("expected: %v, got: %v", want, got)
+				}
+			}
+		})
+	}
+}
+
+// g2Uncompressed and g2Compressed are the Zcash encodings of multiples of the
+// generator, taken from the zkcrypto test vectors.
+var (
+	g2Uncompressed = map[string]struct {
+		scalar int64
+		data   string
+	}{
+		"infinity":      {scalar: 0, data: "400000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"},
+		"generator":     {scalar: 1, data: "13e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be0ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801"},
+		"2*generator":   {scalar: 2, data: "0a4edef9c1ed7f729f520e47730a124fd70662a904ba1074728114d1031e1572c6c886f6b57ec72a6178288c47c335771638533957d540a9d2370f17cc7ed5863bc0b995b8825e0ee1ea1e1e4d00dbae81f14b0bf3611b78c952aacab827a0530f6d4552fa65dd2638b361543f887136a43253d9c66c411697003f7a13c308f5422e1aa0a59c8967acdefd8b6e36ccf30468fb440d82b0630aeb8dca2b5256789a66da69bf91009cbfe6bd221e47aa8ae88dece9764bf3bd999d95d71e4c9899"},
+		"3*generator":   {scalar: 3, data: "09380275bbc8e5dcea7dc4dd7e0550ff2ac480905396eda55062650f8d251c96eb480673937cc6d9d6a44aaa56ca66dc122915c824a0857e2ee414a3dccb23ae691ae54329781315a0c75df1c04d6d7a50a030fc866f09d516020ef82324afae08f239ba329b3967fe48d718a36cfe5f62a7e42e0bf1c1ed714150a166bfbd6bcf6b3b58b975b9edea56d53f23a0e8490b21da7955969e61010c7a1abc1a6f0136961d1e3b20b1a7326ac738fef5c721479dfd948b52fdf2455e44813ecfd892"},
+		"100*generator": {scalar: 100, data: "154652140e62a2ab469f312baae81dc4c4aeb239681be4955a2dc767d359b155798f46a57a40efdf0584c690c06a2e570e47ce1c5a59c4e396ed9c30756a1ed8592a917ea2be7d45db5092791e4806d788cbd12356d8a41c0b99ea290e6d31da014e0339ef7a894ad0102072235e43691bbcff7ff27e27d4b864d1cee537a5ac189c31d3d3f00c077d2b0b90643b32d7131b500860d737094a7be5376d48d94e0a0056b507d587a69f02b50af064e6eaa3a3d837014e464a09885192c0e881a2"},
+		"999*generator": {scalar: 999, data: "158f8116e02e856737dfccdad0a7f100f813c36f9a35349e7ea62facb2824c9277bd34e6581df83deaf3c126e712f15e0b2fd8eb8ae8e2df5281e47abf6334ca1ec378061143ce7c1c804ad9c409c42dab34c78d9d7904a8754cb2817a93c7ea15e29105a7febfd8cd1ba7cc8d7401baef3f2212cd3e44c57e6c08b1f8f2b13a8bf6c6feaac062bed7c77e73c5bfa4e8018a2e642c58de7e025ebabced7472448580b0dc73aae6d4612a7115d00b1c2f8d71030a13bc9f10c03fde318d3cfca3"},
+	}
+	g2Compressed = map[string]struct {
+		scalar int64
+		data   string
+	}{
+		"infinity":      {scalar: 0, data: "c00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"},
+		"generator":     {scalar: 1, data: "93e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb8"},
+		"2*generator":   {scalar: 2, data: "aa4edef9c1ed7f729f520e47730a124fd70662a904ba1074728114d1031e1572c6c886f6b57ec72a6178288c47c335771638533957d540a9d2370f17cc7ed5863bc0b995b8825e0ee1ea1e1e4d00dbae81f14b0bf3611b78c952aacab827a053"},
+		"3*generator":   {scalar: 3, data: "89380275bbc8e5dcea7dc4dd7e0550ff2ac480905396eda55062650f8d251c96eb480673937cc6d9d6a44aaa56ca66dc122915c824a0857e2ee414a3dccb23ae691ae54329781315a0c75df1c04d6d7a50a030fc866f09d516020ef82324afae"},
+		"100*generator": {scalar: 100, data: "954652140e62a2ab469f312baae81dc4c4aeb239681be4955a2dc767d359b155798f46a57a40efdf0584c690c06a2e570e47ce1c5a59c4e396ed9c30756a1ed8592a917ea2be7d45db5092791e4806d788cbd12356d8a41c0b99ea290e6d31da"},
+		"999*generator": {scalar: 999, data: "b58f8116e02e856737dfccdad0a7f100f813c36f9a35349e7ea62facb2824c9277bd34e6581df83deaf3c126e712f15e0b2fd8eb8ae8e2df5281e47abf6334ca1ec378061143ce7c1c804ad9c409c42dab34c78d9d7904a8754cb2817a93c7ea"},
+	}
+)
+
+func TestG2PointMarshal(t *testing.T) {
+	for name, tc := range g2Uncompressed {
+		t.Run(name, func(t *testing.T) {
+			got := hex.EncodeToString(new(G2Point).ScalarBaseMult(big.NewInt(tc.scalar)).Marshal())
+			if got != tc.data {
+				t.Fatalf("expected: %v, got: %v", tc.data, got)
+			}
+		})
+	}
+}
+
+func TestG2PointMarshalCompressed(t *testing.T) {
+	for name, tc := range g2Compressed {
+		t.Run(name, func(t *testing.T) {
+			got := hex.EncodeToString(new(G2Point).ScalarBaseMult(big.NewInt(tc.scalar)).MarshalCompressed())
+			if got != tc.data {
+				t.Fatalf("expected: %v, got: %v", tc.data, got)
+			}
+		})
+	}
+}
+
+func TestG2PointUnmarshal(t *testing.T) {
+	for name, tc := range g2Uncompressed {
+		t.Run(name, func(t *testing.T) {
+			data, _ := hex.DecodeString(tc.data)
+			got := new(G2Point)
+			if err := got.Unmarshal(data); err != nil {
+				t.Fatal(err)
+			}
+			want := new(G2Point).ScalarBaseMult(big.NewInt(tc.scalar)).ToAffine()
+			if got.p != want.p {
+				t.Fatalf("expected: %v, got: %v", want.p, got.p)
+			}
+		})
+	}
+}
+
+func TestG2PointUnmarshalCompressed(t *testing.T) {
+	for name, tc := range g2Compressed {
+		t.Run(name, func(t *testing.T) {
+			data, _ := hex.DecodeString(tc.data)
+			got := new(G2Point)
+			if err := got.UnmarshalCompressed(data); err != nil {
+				t.Fatal(err)
+			}
+			want := new(G2Point).ScalarBaseMult(big.NewInt(tc.scalar)).ToAffine()
+			if got.p != want.p {
+				t.Fatalf("expected: %v, got: %v", want.p, got.p)
+			}
+		})
+	}
+}
+
+func TestG2PointUnmarshalInvalid(t *testing.T) {
+	tests := map[string]struct {
+		compressed bool
+		data       string
+		want       error
+	}{
+		"compressed, short":               {compressed: true, data: "93e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bd", want: ErrInvalidLength},
+		"compressed, no compression flag": {compressed: true, data: "13e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb8", want: ErrInvalidFlags},
+		"compressed, infinity with sign":  {compressed: true, data: "e00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000", want: ErrInvalidInfinity},
+		"compressed, infinity with x":     {compressed: true, data: "c00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000001", want: ErrInvalidInfinity},
+		"compressed, non-canonical x":     {compressed: true, data: "9a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000", want: ErrNonCanonical},
+		"compressed, not on curve":        {compressed: true, data: "800000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000001", want: ErrNotOnCurve},
+		"compressed, not in subgroup":     {compressed: true, data: "800000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000002", want: ErrNotInSubgroup},
+		"uncompressed, short":             {compressed: false, data: "13e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be0ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b828", want: ErrInvalidLength},
+		"uncompressed, compression flag":  {compressed: false, data: "93e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be0ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801", want: ErrInvalidFlags},
+		"uncompressed, sign flag":         {compressed: false, data: "33e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be0ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801", want: ErrInvalidFlags},
+		"uncompressed, infinity with y":   {compressed: false, data: "400000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000001", want: ErrInvalidInfinity},
+		"uncompressed, non-canonical y":   {compressed: false, data: "13e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb81a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000", want: ErrNonCanonical},
+		"uncompressed, not on curve":      {compressed: false, data: "13e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be0ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82800", want: ErrNotOnCurve},
+		"uncompressed, not in subgroup":   {compressed: false, data: "00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000202d27e0ec3356299a346a09ad7dc4ef68a483c3aed53f9139d2f929a3eecebf72082e5e58c6da24ee32e03040c406d4f013a59858b6809fca4d9a3b6539246a70051a3c88899964a42bc9a69cf9acdd9dd387cfa9086b894185b9a46a402be73", want: ErrNotInSubgroup},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			data, _ := hex.DecodeString(tc.data)
+			z := new(G2Point).Set(g2Gen)
+			var err error
+			if tc.compressed {
+				err = z.UnmarshalCompressed(data)
+			} else {
+				err = z.Unmarshal(data)
+			}
+			if err != tc.want {
+				t.Fatalf("expected: %v, got: %v", tc.want, err)
+			}
+			if *z != *g2Gen {
+				t.Fatalf("expected z unchanged, got: %v", z)
+			}
+		})
+	}
+}
+
 func BenchmarkG2(b *testing.B) {
 	x, _ := RandFieldElement(rand.Reader)
 	b.ResetTimer()
@@ -37,3 +558,57 @@ func BenchmarkG2(b *testing.B) {
 		new(G2Point).ScalarBaseMult(x)
 	}
 }
+
+func BenchmarkG2ScalarMult(b *testing.B) {
+	x, _ := RandFieldElement(rand.Reader)
+	p := new(G2Point).HashToPoint([]byte("abc"))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		new(G2Point).ScalarMult(p, x)
+	}
+}
+
+func BenchmarkG2ScalarMultCT(b *testing.B) {
+	x, _ := RandFieldElement(rand.Reader)
+	p := new(G2Point).HashToPoint([]byte("abc"))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		new(G2Point).ScalarMultCT(p, x)
+	}
+}
+
+func BenchmarkNewG2FixedBase(b *testing.B) {
+	p := new(G2Point).HashToPoint([]byte("abc"))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		NewG2FixedBase(p)
+	}
+}
+
+func BenchmarkG2MultiExp(b *testing.B) {
+	for _, n := range []int{16, 256, 4096} {
+		b.Run(fmt.Sprintf("%d points", n), func(b *testing.B) {
+			points, scalars := g2MultiExpInputs(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				G2MultiExp(points, scalars)
+			}
+		})
+	}
+}
+
+func BenchmarkG2DoubleScalarMult(b *testing.B) {
+	x, _ := RandFieldElement(rand.Reader)
+	y, _ := RandFieldElement(rand.Reader)
+	p := new(G2Point).HashToPoint([]byte("abc"))
+	q := new(G2Point).HashToPoint([]byte("def"))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		new(G2Point).DoubleScalarMult(x, p, y, q)
+	}
+}
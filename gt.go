@@ -1,5 +1,298 @@
 package bls12
 
+import (
+	"errors"
+	"math/big"
+)
+
+var (
+	// ErrInvalidGTLength is returned when decoding an element of GT of the
+	// wrong length.
+	ErrInvalidGTLength = errors.New("bls12: invalid GT encoding length")
+
+	// ErrInvalidGTFlags is returned when decoding an element of GT with
+	// invalid flags.
+	ErrInvalidGTFlags = errors.New("bls12: invalid GT encoding flags")
+
+	// ErrNotInGT is returned when decoding an element of Fq¹² that is not in
+	// GT.
+	ErrNotInGT = errors.New("bls12: element is not in GT")
+)
+
+const (
+	// gtByteLen is the length of the encoding of an element of GT.
+	gtByteLen = 12 * fqByteLen
+
+	// gtCompressedByteLen is the length of the compressed encoding of an
+	// element of GT.
+	gtCompressedByteLen = 4 * fqByteLen
+)
+
+// Flags of the compressed encoding of GT. They are stored in the three most
+// significant bits of the encoding, which are always 0 in the encoding of an
+// element of Fq.
+const (
+	gtIdentityMask uint8 = 1 << 6
+	gtFlagsMask          = 1<<7 | gtIdentityMask | 1<<5
+)
+
+// GT is an element of the target group of the pairing, the subgroup of order r
+// of the multiplicative group of Fq¹². The zero value is not an element of GT;
+// use SetOne or Pair.
 type GT struct {
-	fq12
+	f fq12
+}
+
+// Set sets z to x and returns z.
+func (z *GT) Set(x *GT) *GT {
+	z.f.Set(&x.f)
+	return z
+}
+
+// SetOne sets z to the identity element of GT and returns z.
+func (z *GT) SetOne() *GT {
+	z.f.SetOne()
+	return z
+}
+
+// Mul sets z to the product x*y and returns z.
+func (z *GT) Mul(x, y *GT) *GT {
+	z.f.Mul(&x.f, &y.f)
+	return z
+}
+
+// Inv sets z to 1/x and returns z. The inverse of an element of GT is its
+// conjugate.
+func (z *GT) Inv(x *GT) *GT {
+	z.f.Conjugate(&x.f)
+	return z
+}
+
+// Exp sets z to x^k, where k is reduced modulo r, and returns z. The
+// exponentiation runs in constant time with respect to k, but the conversion of
+// the big.Int leaks the length of k.
+//
+// The Frobenius endomorphism acts on GT as the exponentiation by q = -u mod r,
+// so x^u is the conjugate of x^q and k is decomposed in base u by glsDecompose:
+// x^k is a product of four exponentiations by 64 bit scalars, which share their
+// squarings.
+func (z *GT) Exp(x *GT, scalar *big.Int) *GT {
+	k := ctScalar(scalar)
+	ks := glsDecompose(&k)
+
+	// tables[i][j] = x^(j u^i)
+	var tables [len(ks)][ctWindowSize]fq12
+	tables[0][0].SetOne()
+	tables[0][1].Set(&x.f)
+	for j := 2; j < ctWindowSize; j++ {
+		tables[0][j].Mul(&tables[0][j-1], &x.f)
+	}
+	for i := 1; i < len(tables); i++ {
+		for j := range tables[i] {
+			tables[i][j].Frobenius(&tables[i-1][j], 1).Conjugate(&tables[i][j])
+		}
+	}
+
+	ret, t := new(fq12).SetOne(), new(fq12)
+	for w := glsWindows - 1; w >= 0; w-- {
+		for j := 0; j < ctWindowBits; j++ {
+			ret.CyclotomicSqr(ret)
+		}
+		for i := range tables {
+			d := (ks[i] >> uint(w*ctWindowBits)) & (ctWindowSize - 1)
+			for j := range tables[i] {
+				fq12CMov(t, t, &tables[i][j], ctEqual(uint64(j), d))
+			}
+			ret.Mul(ret, t)
+		}
+	}
+
+	z.f.Set(ret)
+	return z
+}
+
+// ExpVartime sets z to x^k, where k is reduced modulo r, and returns z. Unlike
+// Exp, ExpVartime runs in variable time and must not be used with secret
+// scalars: the powers x^(2^i) are computed with compressed squarings and only
+// those for the non-zero bits of k are decompressed.
+func (z *GT) ExpVartime(x *GT, scalar *big.Int) *GT {
+	k := ctScalar(scalar)
+	z.f.CyclotomicExp(&x.f, k[:])
+	return z
+}
+
+// Equal reports whether x is equal to y.
+func (x *GT) Equal(y *GT) bool {
+	return x.f.Equal(&y.f)
+}
+
+// IsOne reports whether x is the identity element of GT.
+func (x *GT) IsOne() bool {
+	return x.f.Equal(new(fq12).SetOne())
+}
+
+// IsInSubgroup reports whether x is in GT. Elements obtained through Pair or
+// Unmarshal are always in GT.
+func (x *GT) IsInSubgroup() bool {
+	return gtIsInSubgroup(&x.f)
+}
+
+// gtIsInSubgroup reports whether x is in GT. x is in the cyclotomic subgroup,
+// of order q⁴ - q² + 1, if x^(q⁴ + 1) = x^(q²), and then in GT, of order r, if
+// x^q = x^x, where x = -u is the curve parameter.
+// See https://eprint.iacr.org/2021/1130.pdf - Section 6.
+func gtIsInSubgroup(x *fq12) bool {
+	if x.Equal(new(fq12)) {
+		return false
+	}
+
+	t0, t1 := new(fq12).Frobenius(x, 4), new(fq12).Frobenius(x, 2)
+	if !t0.Mul(t0, x).Equal(t1) {
+		return false
+	}
+
+	return t0.Frobenius(x, 1).Equal(expByX(x))
+}
+
+// coefficients returns the coefficients of x over Fq, in the order of the
+// encoding.
+func (x *GT) coefficients() [12]*fq {
+	return [12]*fq{
+		&x.f.c0.c0.c0, &x.f.c0.c0.c1, &x.f.c0.c1.c0, &x.f.c0.c1.c1, &x.f.c0.c2.c0, &x.f.c0.c2.c1,
+		&x.f.c1.c0.c0, &x.f.c1.c0.c1, &x.f.c1.c1.c0, &x.f.c1.c1.c1, &x.f.c1.c2.c0, &x.f.c1.c2.c1,
+	}
+}
+
+// Marshal converts x into its 576-byte canonical form: the twelve coefficients
+// of x over Fq, c0.c0.c0, c0.c0.c1, c0.c1.c0, ..., c1.c2.c1, each encoded in
+// 48 bytes in big-endian form.
+func (x *GT) Marshal() []byte {
+	ret := make([]byte, 0, gtByteLen)
+	for _, c := range x.coefficients() {
+		ret = append(ret, new(fq).MontgomeryDecode(c).Bytes()...)
+	}
+
+	return ret
+}
+
+// Unmarshal sets z to the result of converting the output of Marshal back into
+// an element of GT. The encoding must be canonical and the element must be in
+// GT.
+func (z *GT) Unmarshal(data []byte) error {
+	if len(data) != gtByteLen {
+		return ErrInvalidGTLength
+	}
+
+	x := new(GT)
+	for i, c := range x.coefficients() {
+		ci, err := new(fq).SetBytes(data[i*fqByteLen : (i+1)*fqByteLen])
+		if err != nil {
+			return ErrNonCanonical
+		}
+		c.Set(ci)
+	}
+	if !gtIsInSubgroup(&x.f) {
+		return ErrNotInGT
+	}
+	z.Set(x)
+
+	return nil
+}
+
+// MarshalCompressed converts x into its 192-byte compressed form, a third of
+// the size of Marshal: the coefficients c1 and c2 of its image under the torus
+// compression of T6(Fq²), see torusCompress, each encoded as in Marshal. The
+// identity is encoded with the identity flag only.
+func (x *GT) MarshalCompressed() []byte {
+	ret := make([]byte, 0, gtCompressedByteLen)
+	if x.IsOne() {
+		ret = ret[:gtCompressedByteLen]
+		ret[0] |= gtIdentityMask
+		return ret
+	}
+
+	c := torusCompress(&x.f)
+	for _, ci := range [4]*fq{&c.c1.c0, &c.c1.c1, &c.c2.c0, &c.c2.c1} {
+		ret = append(ret, new(fq).MontgomeryDecode(ci).Bytes()...)
+	}
+
+	return ret
+}
+
+// UnmarshalCompressed sets z to the result of converting the output of
+// MarshalCompressed back into an element of GT. The encoding must be canonical
+// and the element must be in GT.
+func (z *GT) UnmarshalCompressed(data []byte) error {
+	if len(data) != gtCompressedByteLen {
+		return ErrInvalidGTLength
+	}
+	flags := data[0] & gtFlagsMask
+	if flags&^gtIdentityMask != 0 {
+		return ErrInvalidGTFlags
+	}
+	if flags == gtIdentityMask {
+		if !isZeroEncoding(data) {
+			return ErrNonCanonical
+		}
+		z.SetOne()
+		return nil
+	}
+
+	var coeffs [4]fq
+	for i := range coeffs {
+		ci, err := new(fq).SetBytes(data[i*fqByteLen : (i+1)*fqByteLen])
+		if err != nil {
+			return ErrNonCanonical
+		}
+		coeffs[i] = *ci
+	}
+	c := &fq6{c1: fq2{coeffs[0], coeffs[1]}, c2: fq2{coeffs[2], coeffs[3]}}
+	if fq2IsZero(&c.c1) == 1 {
+		return ErrNotInGT
+	}
+
+	// c0 = (ξ c2² + 1/3)/c1, see torusCompress.
+	c.c0.Sqr(&c.c2).MulXi(&c.c0)
+	fqAdd(&c.c0.c0, &c.c0.c0, fqOneThird)
+	c.c0.Mul(&c.c0, new(fq2).Inv(&c.c1))
+
+	x := torusDecompress(c)
+	if !gtIsInSubgroup(x) {
+		return ErrNotInGT
+	}
+	z.f.Set(x)
+
+	return nil
+}
+
+// torusCompress returns the compressed form c = (1 + g)/h of x = g + hw, an
+// element of T6(Fq²) = {x : x^(q⁴ - q² + 1) = 1} other than 1.
+//
+// Since x^(q⁶ + 1) = 1, x is in T2(Fq⁶) and x = (c + w)/(c - w), which maps
+// T2(Fq⁶) - {1} to Fq⁶. Furthermore, the norm of x to Fq⁴ = Fq²(w³) is 1: with
+// τ the Frobenius of order 3 of Fq¹²/Fq⁴, τ(v) = ωv and τ(w) = ω²w where ω is
+// a cube root of unity, x τ(x) τ²(x) = 1 simplifies to
+// c τ(c) τ²(c) (1/c)_v = -1/3, that is c0 c1 = ξ c2² + 1/3 for
+// c = c0 + c1 v + c2 v². c1 is never 0 since -1/(3ξ) is not a square in Fq²,
+// so the image of T6(Fq²) - {1} is parameterized by c1 and c2.
+// See https://eprint.iacr.org/2003/039.pdf - Section 5.
+func torusCompress(x *fq12) *fq6 {
+	c := new(fq6).SetOne()
+	c.Add(c, &x.c0)
+	return c.Mul(c, new(fq6).Inv(&x.c1))
+}
+
+// torusDecompress returns (c + w)/(c - w) = (c² + v + 2cw)/(c² - v), the
+// element of T2(Fq⁶) of compressed form c.
+func torusDecompress(c *fq6) *fq12 {
+	v := &fq6{c1: *new(fq2).SetOne()}
+	t0 := new(fq6).Sqr(c)
+	t1 := new(fq6).Sub(t0, v)
+	t1.Inv(t1)
+
+	x := new(fq12)
+	x.c0.Add(t0, v).Mul(&x.c0, t1)
+	x.c1.Add(c, c).Mul(&x.c1, t1)
+
+	return x
 }
@@ -0,0 +1,254 @@
+package bls12
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+// cyclotomicNotInGT returns an element of the cyclotomic subgroup that is not
+// in GT: the easy part of the final exponentiation of a Miller loop.
+func cyclotomicNotInGT() *fq12 {
+	p := miller(&g1Gen.p, &g2Gen.p)
+	f := new(fq12).Conjugate(p)
+	t0 := new(fq12).Inv(p)
+	return f.Mul(f, t0).Mul(f, t0.Frobenius(f, 2))
+}
+
+func TestGTOperations(t *testing.T) {
+	a, b := big.NewInt(0x1234567), big.NewInt(0x7654321)
+	e := Pair(g1Gen, g2Gen)
+	ea := Pair(new(G1Point).ScalarMult(g1Gen, a), g2Gen)
+	eb := Pair(g1Gen, new(G2Point).ScalarMult(g2Gen, b))
+	eab := Pair(new(G1Point).ScalarMult(g1Gen, a), new(G2Point).ScalarMult(g2Gen, b))
+	one := new(GT).SetOne()
+
+	tests := map[string]struct {
+		got, want *GT
+	}{
+		"e^a":               {got: new(GT).Exp(e, a), want: ea},
+		"(e^a)^b":           {got: new(GT).Exp(ea, b), want: eab},
+		"e^(a+b)":           {got: new(GT).Exp(e, new(big.Int).Add(a, b)), want: new(GT).Mul(ea, Pair(new(G1Point).ScalarMult(g1Gen, b), g2Gen))},
+		"e^0":               {got: new(GT).Exp(e, big.NewInt(0)), want: one},
+		"e^r":               {got: new(GT).Exp(e, r), want: one},
+		"e^-a":              {got: new(GT).Exp(e, new(big.Int).Neg(a)), want: new(GT).Inv(ea)},
+		"vartime e^a":       {got: new(GT).ExpVartime(e, a), want: ea},
+		"vartime e^0":       {got: new(GT).ExpVartime(e, big.NewInt(0)), want: one},
+		"vartime e^r":       {got: new(GT).ExpVartime(e, r), want: one},
+		"vartime e^-a":      {got: new(GT).ExpVartime(e, new(big.Int).Neg(a)), want: new(GT).Inv(ea)},
+		"vartime e^(r-1)":   {got: new(GT).ExpVartime(e, new(big.Int).Sub(r, big.NewInt(1))), want: new(GT).Inv(e)},
+		"e * 1/e":           {got: new(GT).Mul(e, new(GT).Inv(e)), want: one},
+		"e^b * e^a":         {got: new(GT).Mul(eb, ea), want: new(GT).Mul(ea, eb)},
+		"pair of infinity":  {got: Pair(new(G1Point), g2Gen), want: one},
+		"inverse of 1 is 1": {got: new(GT).Inv(one), want: one},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if !tc.got.Equal(tc.want) {
+				t.Fatalf("expected: %v, got: %v", tc.want, tc.got)
+			}
+		})
+	}
+
+	if !one.IsOne() || e.IsOne() {
+		t.Fatal("IsOne: expected only the identity to be one")
+	}
+}
+
+func TestGTIsInSubgroup(t *testing.T) {
+	notInGT := cyclotomicNotInGT()
+	if new(fq12).Exp(notInGT, r).Equal(new(fq12).SetOne()) {
+		t.Fatal("expected an element of order greater than r")
+	}
+
+	tests := map[string]struct {
+		input fq12
+		want  bool
+	}{
+		"one":                  {input: *new(fq12).SetOne(), want: true},
+		"pairing":              {input: Pair(g1Gen, g2Gen).f, want: true},
+		"zero":                 {input: fq12{}, want: false},
+		"not cyclotomic":       {input: *miller(&g1Gen.p, &g2Gen.p), want: false},
+		"cyclotomic, not GT":   {input: *notInGT, want: false},
+		"Frobenius of pairing": {input: *new(fq12).Frobenius(&Pair(g1Gen, g2Gen).f, 1), want: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			x := &GT{tc.input}
+			if got := x.IsInSubgroup(); got != tc.want {
+				t.Fatalf("expected: %v, got: %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestGTMarshal(t *testing.T) {
+	tests := map[string]struct {
+		input *GT
+	}{
+		"one":     {input: new(GT).SetOne()},
+		"pairing": {input: Pair(g1Gen, g2Gen)},
+		"pairing of hashed points": {
+			input: Pair(new(G1Point).HashToPoint([]byte("abc")), new(G2Point).HashToPoint([]byte("def"))),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			data := tc.input.Marshal()
+			if len(data) != gtByteLen {
+				t.Fatalf("expected length: %d, got: %d", gtByteLen, len(data))
+			}
+			got := new(GT)
+			if err := got.Unmarshal(data); err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(tc.input) {
+				t.Fatalf("expected: %v, got: %v", tc.input, got)
+			}
+			if !bytes.Equal(got.Marshal(), data) {
+				t.Fatal("expected a canonical encoding")
+			}
+		})
+	}
+
+	one := new(GT).SetOne().Marshal()
+	if want := append(make([]byte, fqByteLen-1), 1); !bytes.Equal(one[:fqByteLen], want) || !bytes.Equal(one[fqByteLen:], make([]byte, gtByteLen-fqByteLen)) {
+		t.Fatalf("unexpected encoding of 1: %x", one)
+	}
+}
+
+func TestGTUnmarshalErrors(t *testing.T) {
+	valid := Pair(g1Gen, g2Gen).Marshal()
+	nonCanonical := append([]byte{}, valid...)
+	copy(nonCanonical[fqByteLen:], q.Bytes())
+	tests := map[string]struct {
+		input []byte
+		want  error
+	}{
+		"empty":              {input: nil, want: ErrInvalidGTLength},
+		"short":              {input: valid[1:], want: ErrInvalidGTLength},
+		"long":               {input: append(append([]byte{}, valid...), 0), want: ErrInvalidGTLength},
+		"non-canonical":      {input: nonCanonical, want: ErrNonCanonical},
+		"zero":               {input: make([]byte, gtByteLen), want: ErrNotInGT},
+		"cyclotomic, not GT": {input: (&GT{*cyclotomicNotInGT()}).Marshal(), want: ErrNotInGT},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := new(GT).Unmarshal(tc.input); err != tc.want {
+				t.Fatalf("expected: %v, got: %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func BenchmarkGTExp(b *testing.B) {
+	e := Pair(g1Gen, g2Gen)
+	k, _ := randInt(rand.Reader, r)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		new(GT).Exp(e, k)
+	}
+}
+
+func TestTorusCompress(t *testing.T) {
+	// c1 is never 0 since -1/(3ξ) is not a square.
+	t0 := new(fq2)
+	t0.c0.Set(fqOneThird)
+	t0.MulXi(t0).Inv(t0).Neg(t0)
+	if fq2IsSquare(t0) == 1 {
+		t.Fatal("expected -1/(3ξ) not to be a square")
+	}
+
+	tests := map[string]struct {
+		input fq12
+	}{
+		"pairing":                  {input: Pair(g1Gen, g2Gen).f},
+		"pairing of hashed points": {input: Pair(new(G1Point).HashToPoint([]byte("abc")), new(G2Point).HashToPoint([]byte("def"))).f},
+		"cyclotomic, not GT":       {input: *cyclotomicNotInGT()},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// x^(q⁴ + 1) = x^(q²)
+			t0, t1 := new(fq12).Frobenius(&tc.input, 4), new(fq12).Frobenius(&tc.input, 2)
+			if !t0.Mul(t0, &tc.input).Equal(t1) {
+				t.Fatal("expected an element of T6(Fq²)")
+			}
+
+			c := torusCompress(&tc.input)
+			lhs, rhs := new(fq2).Mul(&c.c0, &c.c1), new(fq2).Sqr(&c.c2)
+			rhs.MulXi(rhs)
+			fqAdd(&rhs.c0, &rhs.c0, fqOneThird)
+			if *lhs != *rhs {
+				t.Fatalf("expected c0 c1 = ξ c2² + 1/3, got: %v, %v", lhs, rhs)
+			}
+			if got := torusDecompress(c); *got != tc.input {
+				t.Fatalf("expected: %v, got: %v", tc.input, got)
+			}
+		})
+	}
+}
+
+func TestGTMarshalCompressed(t *testing.T) {
+	tests := map[string]struct {
+		input *GT
+	}{
+		"one":     {input: new(GT).SetOne()},
+		"pairing": {input: Pair(g1Gen, g2Gen)},
+		"pairing of hashed points": {
+			input: Pair(new(G1Point).HashToPoint([]byte("abc")), new(G2Point).HashToPoint([]byte("def"))),
+		},
+		"inverse": {input: new(GT).Inv(Pair(g1Gen, g2Gen))},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			data := tc.input.MarshalCompressed()
+			if len(data) != gtCompressedByteLen {
+				t.Fatalf("expected length: %d, got: %d", gtCompressedByteLen, len(data))
+			}
+			got := new(GT)
+			if err := got.UnmarshalCompressed(data); err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(tc.input) {
+				t.Fatalf("expected: %v, got: %v", tc.input, got)
+			}
+			if !bytes.Equal(got.MarshalCompressed(), data) {
+				t.Fatal("expected a canonical encoding")
+			}
+		})
+	}
+}
+
+func TestGTUnmarshalCompressedErrors(t *testing.T) {
+	valid := Pair(g1Gen, g2Gen).MarshalCompressed()
+	withFlags := func(data []byte, flags uint8) []byte {
+		ret := append([]byte{}, data...)
+		ret[0] |= flags
+		return ret
+	}
+	nonCanonical := append([]byte{}, valid...)
+	copy(nonCanonical[fqByteLen:], q.Bytes())
+	notInGT := (&GT{*cyclotomicNotInGT()}).MarshalCompressed()
+	identity := new(GT).SetOne().MarshalCompressed()
+	tests := map[string]struct {
+		input []byte
+		want  error
+	}{
+		"empty":                  {input: nil, want: ErrInvalidGTLength},
+		"uncompressed":           {input: Pair(g1Gen, g2Gen).Marshal(), want: ErrInvalidGTLength},
+		"most significant flag":  {input: withFlags(valid, 1<<7), want: ErrInvalidGTFlags},
+		"least significant flag": {input: withFlags(identity, 1<<5), want: ErrInvalidGTFlags},
+		"non-zero identity":      {input: withFlags(valid, gtIdentityMask), want: ErrNonCanonical},
+		"non-canonical":          {input: nonCanonical, want: ErrNonCanonical},
+		"zero c1":                {input: make([]byte, gtCompressedByteLen), want: ErrNotInGT},
+		"cyclotomic, not GT":     {input: notInGT, want: ErrNotInGT},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := new(GT).UnmarshalCompressed(tc.input); err != tc.want {
+				t.Fatalf("expected: %v, got: %v", tc.want, err)
+			}
+		})
+	}
+}
@@ -1,9 +1,125 @@
 package bls12
 
-func hp(msg []byte) *fq {
-	return &fq{}
+import (
+	"crypto/sha256"
+	"math/big"
+)
+
+const (
+	// fieldExpandLen is the number of uniform bytes used to derive a single
+	// element of fq, L = ceil((ceil(log2(q)) + k) / 8) with k = 128.
+	fieldExpandLen = 64
+
+	// maxDomainLen is the maximum length of a domain separation tag.
+	maxDomainLen = 255
+
+	// maxExpandLen is the maximum number of bytes that expand_message_xmd can
+	// produce with SHA-256.
+	maxExpandLen = 255 * sha256.Size
+)
+
+var (
+	// g1Domain is the domain separation tag used to hash to G1 by default.
+	g1Domain = []byte("BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_RO_NUL_")
+
+	// g2Domain is the domain separation tag used to hash to G2 by default.
+	g2Domain = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_NUL_")
+
+	// g1EncodeDomain is the domain separation tag used to encode to G1 by
+	// default.
+	g1EncodeDomain = []byte("BLS12381G1_XMD:SHA-256_SSWU_NU_")
+
+	// g2EncodeDomain is the domain separation tag used to encode to G2 by
+	// default.
+	g2EncodeDomain = []byte("BLS12381G2_XMD:SHA-256_SSWU_NU_")
+
+	// oversizeDomainPrefix is prepended to tags longer than maxDomainLen
+	// before they are hashed down to a fixed size.
+	oversizeDomainPrefix = []byte("H2C-OVERSIZE-DST-")
+)
+
+// expandMessageXMD expands msg into lenInBytes uniformly random bytes using
+// SHA-256 and the domain separation tag dst. lenInBytes must not exceed
+// maxExpandLen.
+// See https://www.rfc-editor.org/rfc/rfc9380.html#section-5.3.1.
+func expandMessageXMD(msg, dst []byte, lenInBytes int) []byte {
+	if lenInBytes > maxExpandLen {
+		panic("bls12: expand_message_xmd output length out of bounds")
+	}
+
+	// See https://www.rfc-editor.org/rfc/rfc9380.html#section-5.3.3.
+	if len(dst) > maxDomainLen {
+		h := sha256.New()
+		h.Write(oversizeDomainPrefix)
+		h.Write(dst)
+		dst = h.Sum(nil)
+	}
+	dstPrime := append(append([]byte{}, dst...), byte(len(dst)))
+
+	// b0 = H(Z_pad || msg || l_i_b_str || I2OSP(0, 1) || DST_prime)
+	h := sha256.New()
+	h.Write(make([]byte, sha256.BlockSize))
+	h.Write(msg)
+	h.Write([]byte{byte(lenInBytes >> 8), byte(lenInBytes), 0})
+	h.Write(dstPrime)
+	b0 := h.Sum(nil)
+
+	// b1 = H(b0 || I2OSP(1, 1) || DST_prime)
+	h.Reset()
+	h.Write(b0)
+	h.Write([]byte{1})
+	h.Write(dstPrime)
+	bi := h.Sum(nil)
+
+	ell := (lenInBytes + sha256.Size - 1) / sha256.Size
+	uniform := make([]byte, 0, ell*sha256.Size)
+	uniform = append(uniform, bi...)
+	for i := 2; i <= ell; i++ {
+		// bi = H(strxor(b0, b(i-1)) || I2OSP(i, 1) || DST_prime)
+		for j := range bi {
+			bi[j] ^= b0[j]
+		}
+		h.Reset()
+		h.Write(bi)
+		h.Write([]byte{byte(i)})
+		h.Write(dstPrime)
+		bi = h.Sum(bi[:0])
+		uniform = append(uniform, bi...)
+	}
+
+	return uniform[:lenInBytes]
+}
+
+// fqReduce sets z to the big-endian integer b reduced modulo q.
+func fqReduce(z *fq, b []byte) {
+	k := new(big.Int).SetBytes(b)
+	fqK := new(big.Int).Mod(k, q)
+	z.SetInt(fqK)
 }
 
-func hp2(msg []byte) *fq2 {
-	return &fq2{}
+// hashToFq hashes msg to count independent elements of fq.
+// See https://www.rfc-editor.org/rfc/rfc9380.html#section-5.2.
+func hashToFq(msg, dst []byte, count int) []fq {
+	uniform := expandMessageXMD(msg, dst, count*fieldExpandLen)
+	elems := make([]fq, count)
+	for i := range elems {
+		offset := i * fieldExpandLen
+		fqReduce(&elems[i], uniform[offset:offset+fieldExpandLen])
+	}
+
+	return elems
+}
+
+// hashToFq2 hashes msg to count independent elements of fq2.
+// See https://www.rfc-editor.org/rfc/rfc9380.html#section-5.2.
+func hashToFq2(msg, dst []byte, count int) []fq2 {
+	uniform := expandMessageXMD(msg, dst, count*2*fieldExpandLen)
+	elems := make([]fq2, count)
+	for i := range elems {
+		offset := i * 2 * fieldExpandLen
+		fqReduce(&elems[i].c0, uniform[offset:offset+fieldExpandLen])
+		fqReduce(&elems[i].c1, uniform[offset+fieldExpandLen:offset+2*fieldExpandLen])
+	}
+
+	return elems
 }
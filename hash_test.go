@@ -0,0 +1,145 @@
+package bls12
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+var (
+	msgQ128 = "q128_" + strings.Repeat("q", 128)
+	msgA512 = "a512_" + strings.Repeat("a", 512)
+)
+
+// See https://www.rfc-editor.org/rfc/rfc9380.html#appendix-K.1.
+func TestExpandMessageXMD(t *testing.T) {
+	dst := "QUUX-V01-CS02-with-expander-SHA256-128"
+	longDst := "QUUX-V01-CS02-with-expander-SHA256-128-long-DST-" + strings.Repeat("1", 208)
+	tests := map[string]struct {
+		msg, dst   string
+		lenInBytes int
+		want       string
+	}{
+		"empty message, 0x20": {
+			msg: "", dst: dst, lenInBytes: 0x20,
+			want: "68a985b87eb6b46952128911f2a4412bbc302a9d759667f87f7a21d803f07235",
+		},
+		"abc, 0x20": {
+			msg: "abc", dst: dst, lenInBytes: 0x20,
+			want: "d8ccab23b5985ccea865c6c97b6e5b8350e794e603b4b97902f53a8a0d605615",
+		},
+		"a512, 0x20": {
+			msg: msgA512, dst: dst, lenInBytes: 0x20,
+			want: "4623227bcc01293b8c130bf771da8c298dede7383243dc0993d2d94823958c4c",
+		},
+		"abcdef0123456789, 0x80": {
+			msg: "abcdef0123456789", dst: dst, lenInBytes: 0x80,
+			want: "ef904a29bffc4cf9ee82832451c946ac3c8f8058ae97d8d629831a74c6572bd9ebd0df635cd1f208e2038e760c4994984ce73f0d55ea9f22af83ba4734569d4bc95e18350f740c07eef653cbb9f87910d833751825f0ebefa1abe5420bb52be14cf489b37fe1a72f7de2d10be453b2c9d9eb20c7e3f6edc5a60629178d9478df",
+		},
+		"q128, 0x80": {
+			msg: msgQ128, dst: dst, lenInBytes: 0x80,
+			want: "80be107d0884f0d881bb460322f0443d38bd222db8bd0b0a5312a6fedb49c1bbd88fd75d8b9a09486c60123dfa1d73c1cc3169761b17476d3c6b7cbbd727acd0e2c942f4dd96ae3da5de368d26b32286e32de7e5a8cb2949f866a0b80c58116b29fa7fabb3ea7d520ee603e0c25bcaf0b9a5e92ec6a1fe4e0391d1cdbce8c68a",
+		},
+		"oversized dst, abc, 0x20": {
+			msg: "abc", dst: longDst, lenInBytes: 0x20,
+			want: "52dbf4f36cf560fca57dedec2ad924ee9c266341d8f3d6afe5171733b16bbb12",
+		},
+		"oversized dst, empty message, 0x80": {
+			msg: "", dst: longDst, lenInBytes: 0x80,
+			want: "14604d85432c68b757e485c8894db3117992fc57e0e136f71ad987f789a0abc287c47876978e2388a02af86b1e8d1342e5ce4f7aaa07a87321e691f6fba7e0072eecc1218aebb89fb14a0662322d5edbd873f0eb35260145cd4e64f748c5dfe60567e126604bcab1a3ee2dc0778102ae8a5cfd1429ebc0fa6bf1a53c36f55dfc",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := hex.EncodeToString(expandMessageXMD([]byte(tc.msg), []byte(tc.dst), tc.lenInBytes))
+			if got != tc.want {
+				t.Fatalf("expected: %v, got: %v", tc.want, got)
+			}
+		})
+	}
+}
+
+// See https://www.rfc-editor.org/rfc/rfc9380.html#appendix-J.9.1.
+func TestHashToFq(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-BLS12381G1_XMD:SHA-256_SSWU_RO_")
+	tests := map[string]struct {
+		msg  string
+		want [2]string
+	}{
+		"empty message": {
+			msg: "",
+			want: [2]string{
+				"0x0ba14bd907ad64a016293ee7c2d276b8eae71f25a4b941eece7b0d89f17f75cb3ae5438a614fb61d6835ad59f29c564f",
+				"0x019b9bd7979f12657976de2884c7cce192b82c177c80e0ec604436a7f538d231552f0d96d9f7babe5fa3b19b3ff25ac9",
+			},
+		},
+		"abc": {
+			msg: "abc",
+			want: [2]string{
+				"0x0d921c33f2bad966478a03ca35d05719bdf92d347557ea166e5bba579eea9b83e9afa5c088573c2281410369fbd32951",
+				"0x003574a00b109ada2f26a37a91f9d1e740dffd8d69ec0c35e1e9f4652c7dba61123e9dd2e76c655d956e2b3462611139",
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := hashToFq([]byte(tc.msg), dst, 2)
+			for i, ui := range got {
+				want, _ := new(big.Int).SetString(tc.want[i], 0)
+				if ui.Int().Cmp(want) != 0 {
+					t.Fatalf("[u%d] expected: %#x, got: %#x", i, want, ui.Int())
+				}
+			}
+		})
+	}
+}
+
+// See https://www.rfc-editor.org/rfc/rfc9380.html#appendix-J.10.1.
+func TestHashToFq2(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-BLS12381G2_XMD:SHA-256_SSWU_RO_")
+	tests := map[string]struct {
+		msg  string
+		want [2][2]string
+	}{
+		"empty message": {
+			msg: "",
+			want: [2][2]string{
+				{
+					"0x03dbc2cce174e91ba93cbb08f26b917f98194a2ea08d1cce75b2b9cc9f21689d80bd79b594a613d0a68eb807dfdc1cf8",
+					"0x05a2acec64114845711a54199ea339abd125ba38253b70a92c876df10598bd1986b739cad67961eb94f7076511b3b39a",
+				},
+				{
+					"0x02f99798e8a5acdeed60d7e18e9120521ba1f47ec090984662846bc825de191b5b7641148c0dbc237726a334473eee94",
+					"0x145a81e418d4010cc027a68f14391b30074e89e60ee7a22f87217b2f6eb0c4b94c9115b436e6fa4607e95a98de30a435",
+				},
+			},
+		},
+		"a512": {
+			msg: msgA512,
+			want: [2][2]string{
+				{
+					"0x190b513da3e66fc9a3587b78c76d1d132b1152174d0b83e3c1114066392579a45824c5fa17649ab89299ddd4bda54935",
+					"0x12ab625b0fe0ebd1367fe9fac57bb1168891846039b4216b9d94007b674de2d79126870e88aeef54b2ec717a887dcf39",
+				},
+				{
+					"0x0e6a42010cf435fb5bacc156a585e1ea3294cc81d0ceb81924d95040298380b164f702275892cedd81b62de3aba3f6b5",
+					"0x117d9a0defc57a33ed208428cb84e54c85a6840e7648480ae428838989d25d97a0af8e3255be62b25c2a85630d2dddd8",
+				},
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := hashToFq2([]byte(tc.msg), dst, 2)
+			for i, ui := range got {
+				for j, uij := range []*fq{&ui.c0, &ui.c1} {
+					want, _ := new(big.Int).SetString(tc.want[i][j], 0)
+					if uij.Int().Cmp(want) != 0 {
+						t.Fatalf("[u%d.c%d] expected: %#x, got: %#x", i, j, want, uij.Int())
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package bls12
+
+import (
+	"errors"
+	"math/bits"
+	"runtime"
+	"sync"
+)
+
+const (
+	// multiExpMaxWindow is the largest window, in bits, used by the bucket
+	// method.
+	multiExpMaxWindow = 16
+
+	// multiExpBatchSize is the number of bucket additions that share a field
+	// inversion.
+	multiExpBatchSize = 128
+)
+
+// multiExpBatchMinPoints is the number of points from which the bucket
+// additions are batched. The field inversion of a batch costs several hundred
+// multiplications, so batching only pays off with many additions per window.
+// It is a variable so that the tests can lower it.
+var multiExpBatchMinPoints = 1024
+
+// ErrMismatchedLengths is returned by the multi-scalar multiplications when
+// the number of points and scalars differ.
+var ErrMismatchedLengths = errors.New("bls12: mismatched number of points and scalars")
+
+// multiExpWindow returns the window size, in bits, of the bucket method for n
+// points. Each of the 256/c windows costs n additions to fill the buckets and
+// about 2^c additions to sum them, which is roughly minimal for 2^c close to n.
+func multiExpWindow(n int) uint {
+	c := bits.Len(uint(n))
+	if c > 2 {
+		c -= 2
+	}
+	if c < 2 {
+		c = 2
+	}
+	if c > multiExpMaxWindow {
+		c = multiExpMaxWindow
+	}
+	return uint(c)
+}
+
+// multiExpDigits returns the signed digits of the scalars, which must be reduced
+// modulo r, in windows of c bits: k = sum(digits[w][i] 2^(c w)) for each scalar
+// k = scalars[i]. The digits are within [-2^(c-1), 2^(c-1)) so that 2^(c-1)
+// buckets, one for each absolute value, are enough.
+func multiExpDigits(scalars [][scalarWords]uint64, c uint) [][]int32 {
+	// The digits of k < r < 2^255 fit in 256/c + 1 windows, counting the final
+	// carry.
+	numWindows := scalarWords*wordSize/int(c) + 1
+	digits := make([][]int32, numWindows)
+	for w := range digits {
+		digits[w] = make([]int32, len(scalars))
+	}
+
+	mask := uint64(1)<<c - 1
+	half := uint64(1) << (c - 1)
+	for i, k := range scalars {
+		var carry uint64
+		for w := range digits {
+			pos := uint(w) * c
+			var raw uint64
+			if word := pos / wordSize; word < scalarWords {
+				raw = k[word] >> (pos % wordSize)
+				if pos%wordSize+c > wordSize && word+1 < scalarWords {
+					raw |= k[word+1] << (wordSize - pos%wordSize)
+				}
+			}
+			raw = raw&mask + carry
+
+			carry = 0
+			if raw >= half {
+				carry = 1
+				digits[w][i] = int32(int64(raw) - int64(mask+1))
+			} else {
+				digits[w][i] = int32(raw)
+			}
+		}
+	}
+
+	return digits
+}
+
+// multiExpWorkers returns the default number of goroutines of the
+// multi-scalar multiplications.
+func multiExpWorkers() int {
+	return runtime.GOMAXPROCS(0)
+}
+
+// parallel calls f(i) for every i in [0, n) on at most workers goroutines and
+// waits for all the calls to return.
+func parallel(n, workers int, f func(i int)) {
+	if workers > n {
+		workers = n
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	jobs := make(chan int, n)
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for j := 0; j < workers; j++ {
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				f(i)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,69 @@
+package bls12
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func TestMultiExpWindow(t *testing.T) {
+	tests := map[string]struct {
+		n    int
+		want uint
+	}{
+		"one":     {n: 1, want: 2},
+		"eight":   {n: 8, want: 2},
+		"1000":    {n: 1000, want: 8},
+		"1 << 30": {n: 1 << 30, want: multiExpMaxWindow},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := multiExpWindow(tc.n)
+			if got != tc.want {
+				t.Fatalf("expected: %v, got: %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestMultiExpDigits(t *testing.T) {
+	scalars := make([]*big.Int, 0)
+	ks := make([][scalarWords]uint64, 0)
+	for _, k := range scalarMultTests() {
+		scalars = append(scalars, k)
+		ks = append(ks, ctScalar(k))
+	}
+	for _, window := range []uint{2, 3, 5, 8, 13, 16} {
+		t.Run(fmt.Sprintf("window %d", window), func(t *testing.T) {
+			digits := multiExpDigits(ks, window)
+			for i, scalar := range scalars {
+				got := new(big.Int)
+				for w := len(digits) - 1; w >= 0; w-- {
+					d := digits[w][i]
+					if d < -1<<(window-1) || d >= 1<<(window-1) {
+						t.Fatalf("digit %d out of range in window %d", d, w)
+					}
+					got.Lsh(got, window)
+					got.Add(got, big.NewInt(int64(d)))
+				}
+				if want := new(big.Int).Mod(scalar, r); got.Cmp(want) != 0 {
+					t.Fatalf("expected: %v, got: %v", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestParallel(t *testing.T) {
+	for _, workers := range []int{0, 1, 3, 100} {
+		t.Run(fmt.Sprintf("%d workers", workers), func(t *testing.T) {
+			done := make([]bool, 10)
+			parallel(len(done), workers, func(i int) { done[i] = true })
+			for i, ok := range done {
+				if !ok {
+					t.Fatalf("%d was not processed", i)
+				}
+			}
+		})
+	}
+}
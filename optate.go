@@ -1,19 +1,54 @@
 package bls12
 
 import (
+	"errors"
 	"math/big"
+	"math/bits"
 )
 
+// ErrMismatchedPairs is returned by MultiPair when the number of G1 and G2
+// points differ.
+var ErrMismatchedPairs = errors.New("bls12: mismatched number of G1 and G2 points")
+
 var (
-	bigU     = new(big.Int).SetUint64(15132376222941642752)
-	bigHalfU = new(big.Int).Rsh(bigU, 1)
-	uArr     = []uint8{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 1, 0, 1, 1}
-	uArrLen  = len(uArr)
+	bigU    = new(big.Int).SetUint64(15132376222941642752)
+	uArr    = []uint8{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 1, 0, 1, 1}
+	uArrLen = len(uArr)
+
+	// millerLinesLen is the number of line functions of a Miller loop: one
+	// doubling for every bit of u but the leading one and one addition for
+	// every other non-zero bit.
+	millerLinesLen = uArrLen - 1 + bits.OnesCount64(bigU.Uint64()) - 1
 )
 
+// lineFunction holds the coefficients of a line function of the Miller loop.
+// The coefficients c1 and c2 are yet to be multiplied by the coordinates x and
+// y of the G1 point, so that the line function only depends on the G2 point.
+type lineFunction struct {
+	c0, c1, c2 fq2
+}
+
+// Evaluate returns the coefficients of the line function evaluated at the
+// affine point q.
+func (l *lineFunction) Evaluate(q *curvePoint) (*fq2, *fq2, *fq2) {
+	c1, c2 := new(fq2), new(fq2)
+	fqMul(&c1.c0, &l.c1.c0, &q.x)
+	fqMul(&c1.c1, &l.c1.c1, &q.x)
+	fqMul(&c2.c0, &l.c2.c0, &q.y)
+	fqMul(&c2.c1, &l.c2.c1, &q.y)
+	return &l.c0, c1, c2
+}
+
 // doublingAndLine returns the sum r + r and the line function result.
-// See https://arxiv.org/pdf/0904.0854v3.pdf - Doubling on curves with a4 = 0.
 func doublingAndLine(r *twistPoint, q *curvePoint) (*twistPoint, *fq2, *fq2, *fq2) {
+	c0, c1, c2 := doublingStep(r).Evaluate(q)
+	return r, c0, c1, c2
+}
+
+// doublingStep sets r to the sum r + r and returns the line function through
+// r.
+// See https://arxiv.org/pdf/0904.0854v3.pdf - Doubling on curves with a4 = 0.
+func doublingStep(r *twistPoint) *lineFunction {
 	// R ← [2]R
 	t0 := new(fq2)
 	a := new(fq2).Sqr(&r.x)
@@ -31,23 +66,28 @@ func doublingAndLine(r *twistPoint, q *curvePoint) (*twistPoint, *fq2, *fq2, *fq
 	sum.t.Sqr(&sum.z)
 
 	// line function
-	c0 := new(fq2).Add(&r.x, e)
-	c0.Sqr(c0).Sub(c0, t0.Add(b, b).Add(t0, t0).Add(t0, a).Add(t0, g))
-	c1 := new(fq2).Add(e, e)
-	c1.Mul(c1, &r.t).Neg(c1)
-	fqMul(&c1.c0, &c1.c0, &q.x)
-	fqMul(&c1.c1, &c1.c1, &q.x)
-	c2 := new(fq2).Mul(&sum.z, &r.t)
-	c2.Add(c2, c2)
-	fqMul(&c2.c0, &c2.c0, &q.y)
-	fqMul(&c2.c1, &c2.c1, &q.y)
+	l := new(lineFunction)
+	l.c0.Add(&r.x, e)
+	l.c0.Sqr(&l.c0).Sub(&l.c0, t0.Add(b, b).Add(t0, t0).Add(t0, a).Add(t0, g))
+	l.c1.Add(e, e)
+	l.c1.Mul(&l.c1, &r.t).Neg(&l.c1)
+	l.c2.Mul(&sum.z, &r.t)
+	l.c2.Add(&l.c2, &l.c2)
 
-	return r.Set(sum), c0, c1, c2
+	r.Set(sum)
+	return l
 }
 
 // mixedAdditionAndLine returns the sum r + p and the line function result.
-// See https://arxiv.org/pdf/0904.0854v3.pdf - Mixed Addition.
 func mixedAdditionAndLine(r *twistPoint, p *twistPoint, q *curvePoint, r2 *fq2) (*twistPoint, *fq2, *fq2, *fq2) {
+	c0, c1, c2 := mixedAdditionStep(r, p, r2).Evaluate(q)
+	return r, c0, c1, c2
+}
+
+// mixedAdditionStep sets r to the sum r + p, where p is affine and r2 = p.y²,
+// and returns the line function through r and p.
+// See https://arxiv.org/pdf/0904.0854v3.pdf - Mixed Addition.
+func mixedAdditionStep(r *twistPoint, p *twistPoint, r2 *fq2) *lineFunction {
 	// R ← R + P
 	t0 := new(fq2)
 	b := new(fq2).Mul(&p.x, &r.t)
@@ -67,75 +107,142 @@ func mixedAdditionAndLine(r *twistPoint, p *twistPoint, q *curvePoint, r2 *fq2)
 	sum.t.Sqr(&sum.z)
 
 	// line function
+	l := new(lineFunction)
 	t1 := new(fq2).Add(l1, l1) // caches 2L1
-	c0 := new(fq2).Add(r2, &sum.t)
-	c0.Add(c0, t0.Mul(t1, &p.x)).Sub(c0, t0.Add(&p.y, &sum.z).Sqr(t0))
-	c1 := new(fq2).Neg(t1)
-	fqMul(&c1.c0, &c1.c0, &q.x)
-	fqMul(&c1.c1, &c1.c1, &q.x)
-
-	c2 := new(fq2).Add(&sum.z, &sum.z)
-	fqMul(&c2.c0, &c2.c0, &q.y)
-	fqMul(&c2.c1, &c2.c1, &q.y)
+	l.c0.Add(r2, &sum.t)
+	l.c0.Add(&l.c0, t0.Mul(t1, &p.x)).Sub(&l.c0, t0.Add(&p.y, &sum.z).Sqr(t0))
+	l.c1.Neg(t1)
+	l.c2.Add(&sum.z, &sum.z)
 
-	return r.Set(sum), c0, c1, c2
+	r.Set(sum)
+	return l
 }
 
 // finalExp implements the final exponentiation step.
 // See https://eprint.iacr.org/2019/077.pdf - Algorithm 1, step 8.
 func finalExp(p *fq12) *fq12 {
-	// easy part
+	// easy part: f = p^((q⁶ - 1)(q² + 1))
 	f := new(fq12).Conjugate(p) // frobenius
 	t0 := new(fq12).Inv(p)
 	f.Mul(f, t0).Mul(f, t0.Frobenius(f, 2))
 
-	// hard part
-	// note: u is negative.
-	// See https://eprint.iacr.org/2016/130.pdf - Algorithm 2.
-	t0.Sqr(f)
-	t1 := new(fq12).Exp(t0, bigU)
-	t1.Conjugate(t1)
-	t2 := new(fq12).Exp(t1, bigHalfU)
-	t2.Conjugate(t2)
-	t3 := new(fq12).Conjugate(f)
-	t1.Mul(t3, t1).Conjugate(t1).Mul(t1, t2)
-	t2.Exp(t1, bigU).Conjugate(t2)
-	t3.Exp(t2, bigU).Conjugate(t3)
-	t1.Conjugate(t1)
-	t3.Mul(t1, t3)
-	t1.Conjugate(t1).Frobenius(t1, 3)
-	t2.Frobenius(t2, 2)
-	t1.Mul(t1, t2)
-	t2.Exp(t3, bigU).Conjugate(t2).Mul(t2, t0).Mul(t2, f)
-	t1.Mul(t1, t2)
-	t2.Frobenius(t3, 1)
-	return t1.Mul(t1, t2)
+	// hard part: f^(3(q⁴ - q² + 1)/r), where
+	// 3(q⁴ - q² + 1)/r = (x - 1)²(x + q)(x² + q² - 1) + 3 and x = -u.
+	// f is in the cyclotomic subgroup: its inverse is its conjugate.
+	// See https://eprint.iacr.org/2020/875.pdf - Section 4.1.
+	t0 = expByX(f)
+	t0.Mul(t0, new(fq12).Conjugate(f)) // f^(x - 1)
+	t1 := expByX(t0)
+	t0.Conjugate(t0)
+	t0.Mul(t0, t1) // f^(x - 1)²
+	t1 = expByX(t0)
+	t1.Mul(t1, t0.Frobenius(t0, 1)) // f^((x - 1)²(x + q))
+	t0 = expByX(expByX(t1))
+	t0.Mul(t0, new(fq12).Frobenius(t1, 2))
+	t0.Mul(t0, t1.Conjugate(t1)) // f^((x - 1)²(x + q)(x² + q² - 1))
+	t1.CyclotomicSqr(f).Mul(t1, f)
+
+	return t0.Mul(t0, t1)
+}
+
+// expByU returns x^u. x must be an element of the cyclotomic subgroup. u is
+// sparse, so its binary form is used directly, with cyclotomic squarings.
+func expByU(x *fq12) *fq12 {
+	z := new(fq12).Set(x)
+	for i := uArrLen - 2; i >= 0; i-- {
+		z.CyclotomicSqr(z)
+		if uArr[i] == 1 {
+			z.Mul(z, x)
+		}
+	}
+
+	return z
+}
+
+// expByX returns x^(-u), the power of x by the curve parameter x = -u. x must
+// be an element of the cyclotomic subgroup.
+func expByX(x *fq12) *fq12 {
+	z := expByU(x)
+	return z.Conjugate(z)
 }
 
 // miller implements the Miller’s double-and-add algorithm.
 // https://eprint.iacr.org/2016/130.pdf contains useful examples.
 func miller(p *curvePoint, q *twistPoint) *fq12 {
-	pAffine := new(curvePoint).Set(p).ToAffine()
+	return multiMiller([]*curvePoint{p}, []*twistPoint{q})
+}
+
+// multiMiller returns the product of the Miller loops of the pairs (ps[i],
+// qs[i]).
+func multiMiller(ps []*curvePoint, qs []*twistPoint) *fq12 {
+	lines := make([][]lineFunction, len(qs))
+	for i, q := range qs {
+		lines[i] = millerLines(q)
+	}
+
+	return multiMillerLines(ps, lines)
+}
+
+// millerLines returns the line functions of the Miller loop of q, in the order
+// of the loop. It returns nil if q is the point at infinity.
+func millerLines(q *twistPoint) []lineFunction {
+	if q.IsInfinity() {
+		return nil
+	}
+
 	qAffine := new(twistPoint).Set(q).ToAffine()
 	r := new(twistPoint).Set(qAffine)
-	f := new(fq12).SetOne()
-
 	// See https://arxiv.org/pdf/0904.0854v3.pdf - Full addition (precompute R2)
 	r2 := new(fq2).Sqr(&qAffine.y)
 
+	lines := make([]lineFunction, 0, millerLinesLen)
 	// log2(u) - 1 = uArrLen - 2
+	for i := uArrLen - 2; i >= 0; i-- {
+		lines = append(lines, *doublingStep(r))
+		if uArr[i] == 1 {
+			lines = append(lines, *mixedAdditionStep(r, qAffine, r2))
+		}
+	}
+
+	return lines
+}
+
+// multiMillerLines returns the product of the Miller loops of the pairs
+// (ps[i], lines[i]), where lines[i] are the line functions of a G2 point. The
+// loops share the squarings of the accumulator. The pairs that contain the
+// point at infinity contribute 1 to the product.
+// See https://eprint.iacr.org/2010/354.pdf - Section 3.
+func multiMillerLines(ps []*curvePoint, lines [][]lineFunction) *fq12 {
+	pAffine := make([]*curvePoint, 0, len(ps))
+	qLines := make([][]lineFunction, 0, len(lines))
+	for i := range ps {
+		if ps[i].IsInfinity() || lines[i] == nil {
+			continue
+		}
+		pAffine = append(pAffine, new(curvePoint).Set(ps[i]).ToAffine())
+		qLines = append(qLines, lines[i])
+	}
+	f := new(fq12).SetOne()
+
+	k := 0
 	for i := uArrLen - 2; i >= 0; i-- {
 		// skip initial multiplciation (f = 1)
 		if i != (uArrLen - 2) {
 			f.Sqr(f)
 		}
 
-		_, c0, c1, c4 := doublingAndLine(r, pAffine)
-		f.SparseMul014(f, c0, c1, c4)
+		for j := range qLines {
+			c0, c1, c4 := qLines[j][k].Evaluate(pAffine[j])
+			f.SparseMul014(f, c0, c1, c4)
+		}
+		k++
 
 		if uArr[i] == 1 {
-			_, c0, c1, c4 := mixedAdditionAndLine(r, qAffine, pAffine, r2)
-			f.SparseMul014(f, c0, c1, c4)
+			for j := range qLines {
+				c0, c1, c4 := qLines[j][k].Evaluate(pAffine[j])
+				f.SparseMul014(f, c0, c1, c4)
+			}
+			k++
 		}
 	}
 
@@ -145,6 +252,82 @@ func miller(p *curvePoint, q *twistPoint) *fq12 {
 
 // Pair implements the optimal ate pairing algorithm on BLS curves.
 // See https://eprint.iacr.org/2019/077.pdf - Algorithm 1.
-func Pair(g1 *G1Point, g2 *G2Point) *fq12 {
-	return finalExp(miller(&g1.p, &g2.p))
+func Pair(g1 *G1Point, g2 *G2Point) *GT {
+	return &GT{*finalExp(miller(&g1.p, &g2.p))}
+}
+
+// MultiPair returns the product of the pairings of (g1s[i], g2s[i]). It is
+// much faster than separate pairings since the Miller loops share their
+// squarings and the final exponentiation is only computed once.
+func MultiPair(g1s []*G1Point, g2s []*G2Point) (*GT, error) {
+	if len(g1s) != len(g2s) {
+		return nil, ErrMismatchedPairs
+	}
+
+	ps := make([]*curvePoint, len(g1s))
+	qs := make([]*twistPoint, len(g2s))
+	for i := range g1s {
+		ps[i], qs[i] = &g1s[i].p, &g2s[i].p
+	}
+
+	return &GT{*finalExp(multiMiller(ps, qs))}, nil
+}
+
+// PairingCheck reports whether the product of the pairings of (g1s[i], g2s[i])
+// is 1. It returns false if the slices have different lengths.
+// e(a, b) = e(c, d) is checked with PairingCheck([-a, c], [b, d]).
+func PairingCheck(g1s []*G1Point, g2s []*G2Point) bool {
+	p, err := MultiPair(g1s, g2s)
+	if err != nil {
+		return false
+	}
+
+	return p.IsOne()
+}
+
+// G2Prepared is a point of G2 with the precomputed line functions of the
+// Miller loop. Pairings with a prepared point only evaluate the line functions
+// at the G1 point, which pays off for points that are used in many pairings,
+// such as a generator or a long-lived public key.
+type G2Prepared struct {
+	lines []lineFunction
+}
+
+// NewG2Prepared returns the prepared point x.
+func NewG2Prepared(x *G2Point) *G2Prepared {
+	return &G2Prepared{lines: millerLines(&x.p)}
+}
+
+// PairPrepared returns the pairing of g1 and the prepared point g2. It is
+// equal to Pair with the point that g2 was prepared from.
+func PairPrepared(g1 *G1Point, g2 *G2Prepared) *GT {
+	return &GT{*finalExp(multiMillerLines([]*curvePoint{&g1.p}, [][]lineFunction{g2.lines}))}
+}
+
+// MultiPairPrepared returns the product of the pairings of (g1s[i], g2s[i]),
+// where the G2 points are prepared.
+func MultiPairPrepared(g1s []*G1Point, g2s []*G2Prepared) (*GT, error) {
+	if len(g1s) != len(g2s) {
+		return nil, ErrMismatchedPairs
+	}
+
+	ps := make([]*curvePoint, len(g1s))
+	lines := make([][]lineFunction, len(g2s))
+	for i := range g1s {
+		ps[i], lines[i] = &g1s[i].p, g2s[i].lines
+	}
+
+	return &GT{*finalExp(multiMillerLines(ps, lines))}, nil
+}
+
+// PairingCheckPrepared reports whether the product of the pairings of
+// (g1s[i], g2s[i]) is 1, where the G2 points are prepared. It returns false if
+// the slices have different lengths.
+func PairingCheckPrepared(g1s []*G1Point, g2s []*G2Prepared) bool {
+	p, err := MultiPairPrepared(g1s, g2s)
+	if err != nil {
+		return false
+	}
+
+	return p.IsOne()
 }
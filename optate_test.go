@@ -1,6 +1,7 @@
 package bls12
 
 import (
+	"math/big"
 	"testing"
 )
 
@@ -279,6 +280,24 @@ func TestFinalExp(t *testing.T) {
 	}
 }
 
+func TestExpByU(t *testing.T) {
+	tests := map[string]struct {
+		input fq12
+	}{
+		"one":     {input: *new(fq12).SetOne()},
+		"pairing": {input: Pair(g1Gen, g2Gen).f},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			want := new(fq12).Exp(&tc.input, bigU)
+			got := expByU(&tc.input)
+			if *got != *want {
+				t.Fatalf("expected: %v, got: %v", want, got)
+			}
+		})
+	}
+}
+
 // See https://github.com/zkcrypto/pairing/blob/master/src/bls12_381/tests/mod.rs#L23
 func TestPair(t *testing.T) {
 	tests := map[string]struct {
@@ -324,15 +343,133 @@ func TestPair(t *testing.T) {
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
 			got := Pair(&tc.a, &tc.b)
-			if *got != tc.want {
-				t.Fatalf("expected: %v, got: %v", tc.want, got)
+			if got.f != tc.want {
+				t.Fatalf("expected: %v, got: %v", tc.want, got.f)
 			}
 		})
 	}
 }
 
+func BenchmarkFinalExp(b *testing.B) {
+	f := miller(&g1Gen.p, &g2Gen.p)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		finalExp(f)
+	}
+}
+
 func BenchmarkPairing(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		Pair(g1Gen, g2Gen)
 	}
 }
+
+func TestMultiPair(t *testing.T) {
+	a := big.NewInt(0x1234567)
+	aG1 := new(G1Point).ScalarMult(g1Gen, a)
+	aG2 := new(G2Point).ScalarMult(g2Gen, a)
+	h1 := new(G1Point).HashToPoint([]byte("abc"))
+	h2 := new(G2Point).HashToPoint([]byte("def"))
+
+	want := Pair(g1Gen, g2Gen)
+	want.Mul(want, Pair(h1, h2))
+	got, err := MultiPair([]*G1Point{g1Gen, h1}, []*G2Point{g2Gen, h2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *got != *want {
+		t.Fatalf("expected: %v, got: %v", want, got)
+	}
+
+	tests := map[string]struct {
+		g1s  []*G1Point
+		g2s  []*G2Point
+		want bool
+	}{
+		"empty":           {g1s: nil, g2s: nil, want: true},
+		"bilinearity":     {g1s: []*G1Point{aG1, new(G1Point).Neg(g1Gen)}, g2s: []*G2Point{g2Gen, aG2}, want: true},
+		"not bilinear":    {g1s: []*G1Point{aG1, g1Gen}, g2s: []*G2Point{g2Gen, aG2}, want: false},
+		"infinity":        {g1s: []*G1Point{new(G1Point), h1}, g2s: []*G2Point{h2, new(G2Point)}, want: true},
+		"single":          {g1s: []*G1Point{g1Gen}, g2s: []*G2Point{g2Gen}, want: false},
+		"mismatched pair": {g1s: []*G1Point{g1Gen}, g2s: []*G2Point{g2Gen, g2Gen}, want: false},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := PairingCheck(tc.g1s, tc.g2s); got != tc.want {
+				t.Fatalf("expected: %v, got: %v", tc.want, got)
+			}
+		})
+	}
+
+	if _, err := MultiPair([]*G1Point{g1Gen}, nil); err != ErrMismatchedPairs {
+		t.Fatalf("expected: %v, got: %v", ErrMismatchedPairs, err)
+	}
+}
+
+func BenchmarkMultiPair(b *testing.B) {
+	g1s := []*G1Point{g1Gen, g1Gen, g1Gen}
+	g2s := []*G2Point{g2Gen, g2Gen, g2Gen}
+	for i := 0; i < b.N; i++ {
+		MultiPair(g1s, g2s)
+	}
+}
+
+func TestPairPrepared(t *testing.T) {
+	h1 := new(G1Point).HashToPoint([]byte("abc"))
+	h2 := new(G2Point).HashToPoint([]byte("def"))
+	tests := map[string]struct {
+		a G1Point
+		b G2Point
+	}{
+		"generators":     {a: *g1Gen, b: *g2Gen},
+		"hashed":         {a: *h1, b: *h2},
+		"projective":     {a: *new(G1Point).Add(g1Gen, h1), b: *new(G2Point).Add(g2Gen, h2)},
+		"infinity in G1": {a: G1Point{}, b: *h2},
+		"infinity in G2": {a: *h1, b: G2Point{}},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			want := Pair(&tc.a, &tc.b)
+			got := PairPrepared(&tc.a, NewG2Prepared(&tc.b))
+			if *got != *want {
+				t.Fatalf("expected: %v, got: %v", want, got)
+			}
+		})
+	}
+}
+
+func TestMultiPairPrepared(t *testing.T) {
+	a := big.NewInt(0x1234567)
+	aG1 := new(G1Point).ScalarMult(g1Gen, a)
+	aG2 := NewG2Prepared(new(G2Point).ScalarMult(g2Gen, a))
+	gen := NewG2Prepared(g2Gen)
+
+	tests := map[string]struct {
+		g1s  []*G1Point
+		g2s  []*G2Prepared
+		want bool
+	}{
+		"bilinearity":     {g1s: []*G1Point{aG1, new(G1Point).Neg(g1Gen)}, g2s: []*G2Prepared{gen, aG2}, want: true},
+		"not bilinear":    {g1s: []*G1Point{aG1, g1Gen}, g2s: []*G2Prepared{gen, aG2}, want: false},
+		"mismatched pair": {g1s: []*G1Point{g1Gen}, g2s: []*G2Prepared{gen, gen}, want: false},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := PairingCheckPrepared(tc.g1s, tc.g2s); got != tc.want {
+				t.Fatalf("expected: %v, got: %v", tc.want, got)
+			}
+		})
+	}
+
+	if _, err := MultiPairPrepared([]*G1Point{g1Gen}, nil); err != ErrMismatchedPairs {
+		t.Fatalf("expected: %v, got: %v", ErrMismatchedPairs, err)
+	}
+}
+
+func BenchmarkPairPrepared(b *testing.B) {
+	g2 := NewG2Prepared(g2Gen)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		PairPrepared(g1Gen, g2)
+	}
+}
@@ -0,0 +1,238 @@
+package bls12
+
+import (
+	"math/big"
+	"math/bits"
+	"strconv"
+)
+
+const (
+	// scalarWords is the number of 64 bit words of a scalar modulo r.
+	scalarWords = 4
+
+	// ctWindowBits is the width of the windows used by the constant-time scalar
+	// multiplication.
+	ctWindowBits = 4
+
+	// ctWindowSize is the number of multiples of the base point precomputed by
+	// the constant-time scalar multiplication.
+	ctWindowSize = 1 << ctWindowBits
+
+	// ctWindows is the number of windows of a scalar modulo r.
+	ctWindows = scalarWords * wordSize / ctWindowBits
+)
+
+// ctScalar returns b mod r as little-endian 64 bit words. The number of words
+// returned does not depend on the value of b, but ctScalar is not constant time:
+// the reduction and the number of words of b depend on its value, so that the
+// bit length of b leaks. Secret scalars are given as Fr instead.
+func ctScalar(b *big.Int) [scalarWords]uint64 {
+	k := b
+	if b.Sign() < 0 || b.Cmp(r) >= 0 {
+		k = new(big.Int).Mod(b, r)
+	}
+
+	var ret [scalarWords]uint64
+	for i, word := range k.Bits() {
+		if strconv.IntSize == 64 {
+			ret[i] = uint64(word)
+		} else {
+			ret[i/2] |= uint64(word) << uint(32*(i%2))
+		}
+	}
+
+	return ret
+}
+
+// wordsToInt returns the integer of the little-endian words k.
+func wordsToInt(k []uint64) *big.Int {
+	ret := new(big.Int)
+	for i := len(k) - 1; i >= 0; i-- {
+		ret.Lsh(ret, wordSize)
+		ret.Or(ret, new(big.Int).SetUint64(k[i]))
+	}
+	return ret
+}
+
+// ctWindow returns the i-th window of k, counting from the least significant.
+func ctWindow(k *[scalarWords]uint64, i int) uint64 {
+	return (k[i*ctWindowBits/wordSize] >> uint(i*ctWindowBits%wordSize)) & (ctWindowSize - 1)
+}
+
+// ctEqual returns 1 if x is equal to y and 0 otherwise, in constant time.
+func ctEqual(x, y uint64) uint64 {
+	d := x ^ y
+	return 1 ^ ((d | -d) >> (wordSize - 1))
+}
+
+const (
+	// glvHalfWords is the number of 64 bit words of a half scalar of the GLV
+	// decomposition.
+	glvHalfWords = 2
+
+	// glvWindows is the number of windows of a half scalar of the GLV
+	// decomposition.
+	glvWindows = glvHalfWords * wordSize / ctWindowBits
+
+	// glsWindows is the number of windows of a part of the GLS decomposition.
+	glsWindows = wordSize / ctWindowBits
+
+	// wnafWidth is the width of the non-adjacent forms used by the variable-time
+	// scalar multiplications.
+	wnafWidth = 5
+
+	// wnafTableSize is the number of odd multiples of a point precomputed for a
+	// non-adjacent form of width wnafWidth.
+	wnafTableSize = 1 << (wnafWidth - 2)
+)
+
+var (
+	// glvU2 is x², the eigenvalue of the endomorphism -sigma on G1.
+	glvU2 = [glvHalfWords]uint64{0x0000000100000000, 0xac45a4010001a402}
+
+	// glvG is floor(2^256 / x²), used to round the GLV decomposition.
+	glvG = [3]uint64{0x63f6e522f6cfee2e, 0x7c6becf1e01faadd, 0x1}
+)
+
+// glvDecompose returns k0 and k1 such that k = k0 + k1 x² with 0 <= k0, k1 < x²,
+// in constant time. k must be smaller than r.
+//
+// Since r = x⁴ - x² + 1, (x², -1) is a short vector of the lattice of the
+// decompositions of 0, {(a, b) : a + b x² = 0 mod r}, and (k0, k1) is the
+// closest point to (k, 0) on the lattice coset spanned by it. The rounding is
+// computed with the precomputed quotient glvG instead of a division.
+// See https://www.iacr.org/archive/crypto2001/21390189.pdf - Section 4.
+func glvDecompose(k *[scalarWords]uint64) (k0, k1 [scalarWords]uint64) {
+	// c = floor(k glvG / 2^256) is either floor(k / x²) or floor(k / x²) - 1.
+	var prod [scalarWords + 3]uint64
+	mulWords(prod[:], k[:], glvG[:])
+	c := [glvHalfWords]uint64{prod[scalarWords], prod[scalarWords+1]}
+
+	// k0 = k - c x², which is smaller than 2x².
+	var cu2 [scalarWords]uint64
+	mulWords(cu2[:], c[:], glvU2[:])
+	var borrow uint64
+	for i := range k0 {
+		k0[i], borrow = bits.Sub64(k[i], cu2[i], borrow)
+	}
+
+	// if k0 >= x², then k0 -= x² and c += 1.
+	var d [glvHalfWords + 1]uint64
+	borrow = 0
+	for i := range d {
+		var u2i uint64
+		if i < glvHalfWords {
+			u2i = glvU2[i]
+		}
+		d[i], borrow = bits.Sub64(k0[i], u2i, borrow)
+	}
+	mask := borrow - 1
+	for i := range d {
+		k0[i] ^= mask & (k0[i] ^ d[i])
+	}
+	var carry uint64
+	c[0], carry = bits.Add64(c[0], mask&1, 0)
+	c[1], _ = bits.Add64(c[1], 0, carry)
+	copy(k1[:], c[:])
+
+	return k0, k1
+}
+
+// mulWords sets z to the product x*y of little-endian words, in constant time.
+// len(z) must be at least len(x) + len(y).
+func mulWords(z, x, y []uint64) {
+	for i := range z {
+		z[i] = 0
+	}
+	for i, yi := range y {
+		var carry uint64
+		for j, xj := range x {
+			hi, lo := bits.Mul64(xj, yi)
+			var c uint64
+			lo, c = bits.Add64(lo, z[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			z[i+j] = lo
+			carry = hi
+		}
+		z[i+len(x)] = carry
+	}
+}
+
+// wnaf returns the width-w non-adjacent form of k, least significant digit
+// first. Every non-zero digit is odd and smaller than 2^(w-1) in absolute value.
+func wnaf(k [scalarWords]uint64, w uint) []int8 {
+	naf := make([]int8, 0, scalarWords*wordSize+1)
+	mod := uint64(1) << w
+	for k != [scalarWords]uint64{} {
+		var d int8
+		if k[0]&1 == 1 {
+			r := k[0] & (mod - 1)
+			if r >= mod/2 {
+				// k -= r - 2^w
+				d = int8(int64(r) - int64(mod))
+				var carry uint64
+				k[0], carry = bits.Add64(k[0], mod-r, 0)
+				for i := 1; i < scalarWords && carry != 0; i++ {
+					k[i], carry = bits.Add64(k[i], 0, carry)
+				}
+			} else {
+				d = int8(r)
+				k[0] -= r
+			}
+		}
+		naf = append(naf, d)
+
+		for i := 0; i < scalarWords-1; i++ {
+			k[i] = k[i]>>1 | k[i+1]<<(wordSize-1)
+		}
+		k[scalarWords-1] >>= 1
+	}
+
+	return naf
+}
+
+var (
+	// glsU is x, the eigenvalue of the endomorphism -psi on G2.
+	glsU = uint64(0xd201000000010000)
+
+	// glsG is floor(2^128 / x), used to round the GLS decomposition.
+	glsG = [glvHalfWords]uint64{0x381204ca56cd56b5, 0x1}
+)
+
+// glsDecompose returns k0, k1, k2 and k3 such that
+// k = k0 + k1 x + k2 x² + k3 x³ with 0 <= ki < x, in constant time. k must be
+// smaller than r.
+//
+// Since r = x⁴ - x² + 1 < x⁴, the digits of k in base x are a decomposition of
+// k on the lattice {(a, b, c, d) : a + b x + c x² + d x³ = 0 mod r}. k is first
+// split in base x² by glvDecompose and each half is then split in base x.
+// See https://eprint.iacr.org/2008/194.pdf.
+func glsDecompose(k *[scalarWords]uint64) (ks [4]uint64) {
+	lo, hi := glvDecompose(k)
+	ks[0], ks[1] = glsSplit(&lo)
+	ks[2], ks[3] = glsSplit(&hi)
+	return ks
+}
+
+// glsSplit returns k0 and k1 such that k = k0 + k1 x with 0 <= k0, k1 < x, in
+// constant time. k must be smaller than x².
+func glsSplit(k *[scalarWords]uint64) (k0, k1 uint64) {
+	// c = floor(k glsG / 2^128) is either floor(k / x) or floor(k / x) - 1.
+	var prod [2 * glvHalfWords]uint64
+	mulWords(prod[:], k[:glvHalfWords], glsG[:])
+	c := prod[glvHalfWords]
+
+	// d = k - c x, which is smaller than 2x.
+	hi, lo := bits.Mul64(c, glsU)
+	d0, borrow := bits.Sub64(k[0], lo, 0)
+	d1, _ := bits.Sub64(k[1], hi, borrow)
+
+	// if d >= x, then d -= x and c += 1.
+	e0, borrow := bits.Sub64(d0, glsU, 0)
+	_, borrow = bits.Sub64(d1, 0, borrow)
+	mask := borrow - 1
+
+	return d0 ^ (mask & (d0 ^ e0)), c + (mask & 1)
+}
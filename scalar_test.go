@@ -0,0 +1,270 @@
+package bls12
+
+import (
+	"crypto/rand"
+	"math"
+	"math/big"
+	"os"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestCTScalar(t *testing.T) {
+	rMinusOne := new(big.Int).Sub(r, big.NewInt(1))
+	tests := map[string]struct {
+		input *big.Int
+		want  [scalarWords]uint64
+	}{
+		"zero":     {input: big.NewInt(0), want: [scalarWords]uint64{}},
+		"one":      {input: big.NewInt(1), want: [scalarWords]uint64{1}},
+		"r - 1":    {input: rMinusOne, want: [scalarWords]uint64{0xffffffff00000000, 0x53bda402fffe5bfe, 0x3339d80809a1d805, 0x73eda753299d7d48}},
+		"r":        {input: r, want: [scalarWords]uint64{}},
+		"r + 2":    {input: new(big.Int).Add(r, big.NewInt(2)), want: [scalarWords]uint64{2}},
+		"negative": {input: big.NewInt(-1), want: [scalarWords]uint64{0xffffffff00000000, 0x53bda402fffe5bfe, 0x3339d80809a1d805, 0x73eda753299d7d48}},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := ctScalar(tc.input)
+			if got != tc.want {
+				t.Fatalf("expected: %x, got: %x", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCTWindow(t *testing.T) {
+	k := [scalarWords]uint64{0x0123456789abcdef, 0, 0, 0xf000000000000000}
+	tests := map[string]struct {
+		i    int
+		want uint64
+	}{
+		"first":        {i: 0, want: 0xf},
+		"second":       {i: 1, want: 0xe},
+		"end of word":  {i: 15, want: 0},
+		"empty word":   {i: 16, want: 0},
+		"last":         {i: ctWindows - 1, want: 0xf},
+		"before last":  {i: ctWindows - 2, want: 0},
+		"middle first": {i: 8, want: 0x7},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := ctWindow(&k, tc.i)
+			if got != tc.want {
+				t.Fatalf("expected: %x, got: %x", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestGLVDecompose(t *testing.T) {
+	u2 := new(big.Int).Mul(bigU, bigU)
+	if got := wordsToInt(glvU2[:]); got.Cmp(u2) != 0 {
+		t.Fatalf("glvU2: expected: %v, got: %v", u2, got)
+	}
+	for name, scalar := range scalarMultTests() {
+		t.Run(name, func(t *testing.T) {
+			k := ctScalar(scalar)
+			k0, k1 := glvDecompose(&k)
+			bigK0, bigK1 := wordsToInt(k0[:]), wordsToInt(k1[:])
+			if bigK0.Cmp(u2) >= 0 || bigK1.Cmp(u2) >= 0 {
+				t.Fatalf("expected halves smaller than x², got: %v, %v", bigK0, bigK1)
+			}
+			want := wordsToInt(k[:])
+			got := new(big.Int).Add(bigK0, bigK1.Mul(bigK1, u2))
+			if got.Cmp(want) != 0 {
+				t.Fatalf("expected: %v, got: %v", want, got)
+			}
+		})
+	}
+}
+
+func TestGLSDecompose(t *testing.T) {
+	if got := new(big.Int).SetUint64(glsU); got.Cmp(bigU) != 0 {
+		t.Fatalf("glsU: expected: %v, got: %v", bigU, got)
+	}
+	for name, scalar := range scalarMultTests() {
+		t.Run(name, func(t *testing.T) {
+			k := ctScalar(scalar)
+			ks := glsDecompose(&k)
+			got := new(big.Int)
+			for i := len(ks) - 1; i >= 0; i-- {
+				if ks[i] >= glsU {
+					t.Fatalf("expected parts smaller than x, got: %x", ks)
+				}
+				got.Mul(got, bigU)
+				got.Add(got, new(big.Int).SetUint64(ks[i]))
+			}
+			if want := wordsToInt(k[:]); got.Cmp(want) != 0 {
+				t.Fatalf("expected: %v, got: %v", want, got)
+			}
+		})
+	}
+}
+
+func TestWNAF(t *testing.T) {
+	for name, scalar := range scalarMultTests() {
+		t.Run(name, func(t *testing.T) {
+			k := ctScalar(scalar)
+			naf := wnaf(k, wnafWidth)
+			got := new(big.Int)
+			for i := len(naf) - 1; i >= 0; i-- {
+				d := naf[i]
+				if d != 0 && (d%2 == 0 || d >= 1<<(wnafWidth-1) || d <= -1<<(wnafWidth-1)) {
+					t.Fatalf("invalid digit %d at %d", d, i)
+				}
+				if d != 0 {
+					for j := 1; j < wnafWidth && i+j < len(naf); j++ {
+						if naf[i+j] != 0 {
+							t.Fatalf("non-zero digits at %d and %d", i, i+j)
+						}
+					}
+				}
+				got.Lsh(got, 1)
+				got.Add(got, big.NewInt(int64(d)))
+			}
+			if want := wordsToInt(k[:]); got.Cmp(want) != 0 {
+				t.Fatalf("expected: %v, got: %v", want, got)
+			}
+		})
+	}
+}
+
+// dudectSamples is the number of measurements per class taken by the
+// statistical timing tests.
+const dudectSamples = 1000
+
+// dudectThreshold is the value of the t-statistic above which the timings of
+// the two classes are considered distinguishable.
+// See https://eprint.iacr.org/2016/1123.pdf.
+const dudectThreshold = 10
+
+// skipUnlessDudect skips the statistical timing tests unless the BLS12_DUDECT
+// environment variable is set to 1: their outcome depends on the load of the
+// machine.
+func skipUnlessDudect(t *testing.T) {
+	if os.Getenv("BLS12_DUDECT") != "1" {
+		t.Skip("skipping statistical timing test, set BLS12_DUDECT=1 to run it")
+	}
+}
+
+// welchT returns Welch's t-statistic of the samples a and b.
+func welchT(a, b []float64) float64 {
+	meanVar := func(x []float64) (float64, float64) {
+		var mean, m2 float64
+		for i, xi := range x {
+			d := xi - mean
+			mean += d / float64(i+1)
+			m2 += d * (xi - mean)
+		}
+		return mean, m2 / float64(len(x)-1)
+	}
+	meanA, varA := meanVar(a)
+	meanB, varB := meanVar(b)
+
+	return (meanA - meanB) / math.Sqrt(varA/float64(len(a))+varB/float64(len(b)))
+}
+
+// dudect measures f with a fixed scalar and with random scalars, in random
+// order, and returns the t-statistic of the two classes of timings. As in
+// dudect, the measurements above the 90th percentile are discarded to reduce
+// the noise.
+func dudect(t *testing.T, f func(k *big.Int)) float64 {
+	fixed := big.NewInt(1)
+	random := make([]*big.Int, dudectSamples)
+	for i := range random {
+		random[i], _ = randInt(rand.Reader, r)
+	}
+	classes := make([]byte, 2*dudectSamples)
+	if _, err := rand.Read(classes); err != nil {
+		t.Fatal(err)
+	}
+
+	var timings [2][]float64
+	for i := range classes {
+		class := classes[i] & 1
+		k := fixed
+		if class == 1 {
+			k = random[i%dudectSamples]
+		}
+		start := time.Now()
+		f(k)
+		timings[class] = append(timings[class], float64(time.Since(start)))
+	}
+
+	all := append(append([]float64{}, timings[0]...), timings[1]...)
+	sort.Float64s(all)
+	cutoff := all[len(all)*9/10]
+	for class := range timings {
+		cropped := timings[class][:0]
+		for _, d := range timings[class] {
+			if d <= cutoff {
+				cropped = append(cropped, d)
+			}
+		}
+		timings[class] = cropped
+	}
+
+	return welchT(timings[0], timings[1])
+}
+
+func TestG1PointScalarMultCTTiming(t *testing.T) {
+	skipUnlessDudect(t)
+
+	p := new(G1Point).HashToPoint([]byte("abc"))
+	got := dudect(t, func(k *big.Int) { new(G1Point).ScalarMultCT(p, k) })
+	if math.Abs(got) > dudectThreshold {
+		t.Fatalf("timing leakage detected: |t| = %.2f > %d", math.Abs(got), dudectThreshold)
+	}
+}
+
+func TestG2PointScalarMultCTTiming(t *testing.T) {
+	skipUnlessDudect(t)
+
+	p := new(G2Point).HashToPoint([]byte("abc"))
+	got := dudect(t, func(k *big.Int) { new(G2Point).ScalarMultCT(p, k) })
+	if math.Abs(got) > dudectThreshold {
+		t.Fatalf("timing leakage detected: |t| = %.2f > %d", math.Abs(got), dudectThreshold)
+	}
+}
+
+func TestG1PointScalarBaseMultTiming(t *testing.T) {
+	skipUnlessDudect(t)
+
+	g1GenFixedBase()
+	got := dudect(t, func(k *big.Int) { new(G1Point).ScalarBaseMult(k) })
+	if math.Abs(got) > dudectThreshold {
+		t.Fatalf("timing leakage detected: |t| = %.2f > %d", math.Abs(got), dudectThreshold)
+	}
+}
+
+func TestG2PointScalarBaseMultTiming(t *testing.T) {
+	skipUnlessDudect(t)
+
+	g2GenFixedBase()
+	got := dudect(t, func(k *big.Int) { new(G2Point).ScalarBaseMult(k) })
+	if math.Abs(got) > dudectThreshold {
+		t.Fatalf("timing leakage detected: |t| = %.2f > %d", math.Abs(got), dudectThreshold)
+	}
+}
+
+func TestG1PointDoubleScalarMultCTTiming(t *testing.T) {
+	skipUnlessDudect(t)
+
+	p := new(G1Point).HashToPoint([]byte("abc"))
+	q := new(G1Point).HashToPoint([]byte("def"))
+	got := dudect(t, func(k *big.Int) { new(G1Point).DoubleScalarMultCT(k, p, k, q) })
+	if math.Abs(got) > dudectThreshold {
+		t.Fatalf("timing leakage detected: |t| = %.2f > %d", math.Abs(got), dudectThreshold)
+	}
+}
+
+func TestGTExpTiming(t *testing.T) {
+	skipUnlessDudect(t)
+
+	e := Pair(g1Gen, g2Gen)
+	got := dudect(t, func(k *big.Int) { new(GT).Exp(e, k) })
+	if math.Abs(got) > dudectThreshold {
+		t.Fatalf("timing leakage detected: |t| = %.2f > %d", math.Abs(got), dudectThreshold)
+	}
+}
@@ -9,6 +9,9 @@ import (
 	bls12 "github.com/videocoin/go-bls12-381"
 )
 
+// g2Gen is the prepared generator of G2.
+var g2Gen = bls12.NewG2Prepared(new(bls12.G2Point).ScalarBaseMult(big.NewInt(1)))
+
 // PublicKey represents a BLS public key.
 type PublicKey struct {
 	bls12.G2Point
@@ -20,10 +23,21 @@ func (z *PublicKey) Aggregate(x, y *PublicKey) *PublicKey {
 	return z
 }
 
+// Prepare returns the public key with the precomputed values of the pairing.
+// Signatures are faster to verify with a prepared public key.
+func (pub *PublicKey) Prepare() *PreparedPublicKey {
+	return &PreparedPublicKey{*bls12.NewG2Prepared(&pub.G2Point)}
+}
+
+// PreparedPublicKey represents a BLS public key prepared for verification.
+type PreparedPublicKey struct {
+	bls12.G2Prepared
+}
+
 // PrivateKey represents a BLS private key.
 type PrivateKey struct {
 	PublicKey
-	Secret *big.Int
+	Secret *bls12.Fr
 }
 
 // Public returns the public key corresponding to priv.
@@ -43,14 +57,14 @@ func (z *Signature) Aggregate(x, y *Signature) {
 
 // GenerateKey generates a public and private key pair.
 func GenerateKey(reader io.Reader) (*PrivateKey, error) {
-	k, err := bls12.RandFieldElement(reader)
+	k, err := bls12.RandFr(reader)
 	if err != nil {
 		return nil, err
 	}
 
 	priv := &PrivateKey{
 		Secret:    k,
-		PublicKey: PublicKey{*new(bls12.G2Point).ScalarBaseMult(k).ToAffine()},
+		PublicKey: PublicKey{*new(bls12.G2Point).ScalarBaseMultFr(k).ToAffine()},
 	}
 
 	return priv, nil
@@ -58,20 +72,30 @@ func GenerateKey(reader io.Reader) (*PrivateKey, error) {
 
 // Sign signs a hash using the private key, priv.
 func Sign(priv *PrivateKey, hash []byte) *Signature {
-	return &Signature{*new(bls12.G1Point).ScalarMult(new(bls12.G1Point).HashToPoint(hash), priv.Secret)}
+	return &Signature{*new(bls12.G1Point).ScalarMultCTFr(new(bls12.G1Point).HashToPoint(hash), priv.Secret)}
 }
 
 // Verify verifies the signature of hash using the public key, pub. Its
 // return value records whether the signature is valid.
 func Verify(hash []byte, sig *Signature, pubKey *PublicKey) bool {
-	return bls12.Pair(&sig.G1Point, bls12.G2Gen).Equal(bls12.Pair(new(bls12.G1Point).HashToPoint(hash), &pubKey.G2Point))
+	return VerifyPrepared(hash, sig, pubKey.Prepare())
+}
+
+// VerifyPrepared verifies the signature of hash using the prepared public key,
+// pub. Its return value records whether the signature is valid.
+func VerifyPrepared(hash []byte, sig *Signature, pubKey *PreparedPublicKey) bool {
+	// e(sig, g2) = e(H(m), pk)
+	return bls12.PairingCheckPrepared(
+		[]*bls12.G1Point{new(bls12.G1Point).Neg(&sig.G1Point), new(bls12.G1Point).HashToPoint(hash)},
+		[]*bls12.G2Prepared{g2Gen, &pubKey.G2Prepared},
+	)
 }
 
 // VerifyAggregateCommon verifies that a signature is valid, for a collection
 // of public keys and a common message. Its return value records whether the
 // signature is valid.
 func VerifyAggregateCommon(hash []byte, multiSig *Signature, pubKeys []*PublicKey) bool {
-	return bls12.Pair(&multiSig.G1Point, bls12.G2Gen).Equal(bls12.Pair(new(bls12.G1Point).HashToPoint(hash), &AggregatePublicKeys(pubKeys).G2Point))
+	return Verify(hash, multiSig, AggregatePublicKeys(pubKeys))
 }
 
 // VerifyAggregateDistinct verifies that a signature is valid, for a collection
@@ -103,14 +127,17 @@ func VerifyAggregate(hashes [][]byte, multiSig *Signature, pubKeys []*PublicKey)
 		return false
 	}
 
-	t0 := new(bls12.G1Point).HashToPoint(hashes[0])
-	pairing := bls12.Pair(t0, &pubKeys[0].G2Point)
-	for i, pi := range pubKeys[1:] {
-		t0.HashToPoint(hashes[i])
-		pairing.Add(pairing, bls12.Pair(t0, &pi.G2Point))
+	// e(sig, g2) = e(H(m1), pk1) ... e(H(mn), pkn)
+	g1s := make([]*bls12.G1Point, 0, len(hashes)+1)
+	g2s := make([]*bls12.G2Prepared, 0, len(hashes)+1)
+	g1s = append(g1s, new(bls12.G1Point).Neg(&multiSig.G1Point))
+	g2s = append(g2s, g2Gen)
+	for i, hash := range hashes {
+		g1s = append(g1s, new(bls12.G1Point).HashToPoint(hash))
+		g2s = append(g2s, bls12.NewG2Prepared(&pubKeys[i].G2Point))
 	}
 
-	return bls12.Pair(&multiSig.G1Point, bls12.G2Gen).Equal(pairing)
+	return bls12.PairingCheckPrepared(g1s, g2s)
 }
 
 // AggregateSignatures aggregates multiple signatures into one signature.
@@ -37,3 +37,96 @@ func TestZeroHashSignature(t *testing.T) {
 		t.Errorf("zero hash signature verify failed")
 	}
 }
+
+func TestVerifyAggregate(t *testing.T) {
+	hashes := [][]byte{[]byte("message 1"), []byte("message 2"), []byte("message 3")}
+	pubKeys := make([]*PublicKey, len(hashes))
+	sigs := make([]*Signature, len(hashes))
+	for i, hash := range hashes {
+		priv, err := GenerateKey(rand.Reader)
+		if err != nil {
+			panic(err)
+		}
+		pubKeys[i] = &priv.PublicKey
+		sigs[i] = Sign(priv, hash)
+	}
+	multiSig := AggregateSignatures(sigs)
+
+	if valid := VerifyAggregate(hashes, multiSig, pubKeys); !valid {
+		t.Errorf("VerifyAggregate failed")
+	}
+
+	pubKeys[0], pubKeys[1] = pubKeys[1], pubKeys[0]
+	if valid := VerifyAggregate(hashes, multiSig, pubKeys); valid {
+		t.Errorf("VerifyAggregate accepted swapped public keys")
+	}
+}
+
+func TestVerifyAggregateCommon(t *testing.T) {
+	hashed := []byte("testing")
+	pubKeys := make([]*PublicKey, 3)
+	sigs := make([]*Signature, len(pubKeys))
+	for i := range pubKeys {
+		priv, err := GenerateKey(rand.Reader)
+		if err != nil {
+			panic(err)
+		}
+		pubKeys[i] = &priv.PublicKey
+		sigs[i] = Sign(priv, hashed)
+	}
+	multiSig := AggregateSignatures(sigs)
+
+	if valid := VerifyAggregateCommon(hashed, multiSig, pubKeys); !valid {
+		t.Errorf("VerifyAggregateCommon failed")
+	}
+
+	if valid := VerifyAggregateCommon(hashed, multiSig, pubKeys[1:]); valid {
+		t.Errorf("VerifyAggregateCommon accepted a missing public key")
+	}
+}
+
+func TestVerifyPrepared(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	hashed := []byte("testing")
+	sig := Sign(priv, hashed)
+	pub := priv.PublicKey.Prepare()
+
+	if valid := VerifyPrepared(hashed, sig, pub); !valid {
+		t.Errorf("VerifyPrepared failed")
+	}
+
+	hashed[0] ^= 0xff
+	if valid := VerifyPrepared(hashed, sig, pub); valid {
+		t.Errorf("VerifyPrepared always works!")
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	priv, err := GenerateKey(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	hashed := []byte("testing")
+	sig := Sign(priv, hashed)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Verify(hashed, sig, &priv.PublicKey)
+	}
+}
+
+func BenchmarkVerifyPrepared(b *testing.B) {
+	priv, err := GenerateKey(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	hashed := []byte("testing")
+	sig := Sign(priv, hashed)
+	pub := priv.PublicKey.Prepare()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		VerifyPrepared(hashed, sig, pub)
+	}
+}
@@ -9,6 +9,9 @@ import (
 	bls12 "github.com/videocoin/go-bls12-381"
 )
 
+// g1Gen is the generator of G1.
+var g1Gen = new(bls12.G1Point).ScalarBaseMult(big.NewInt(1))
+
 // PublicKey represents a BLS public key.
 type PublicKey struct {
 	bls12.G1Point
@@ -23,7 +26,7 @@ func (z *PublicKey) Aggregate(x, y *PublicKey) *PublicKey {
 // PrivateKey represents a BLS private key.
 type PrivateKey struct {
 	PublicKey
-	Secret *big.Int
+	Secret *bls12.Fr
 }
 
 // Public returns the public key corresponding to priv.
@@ -41,16 +44,16 @@ func (z *Signature) Aggregate(x, y *Signature) {
 	z.Add(&x.G2Point, &y.G2Point)
 }
 
-func privKeyFromScalar(k *big.Int) *PrivateKey {
+func privKeyFromScalar(k *bls12.Fr) *PrivateKey {
 	priv := new(PrivateKey)
 	priv.Secret = k
-	priv.PublicKey = PublicKey{*new(bls12.G1Point).ScalarBaseMult(k).ToAffine()}
+	priv.PublicKey = PublicKey{*new(bls12.G1Point).ScalarBaseMultFr(k).ToAffine()}
 	return priv
 }
 
 // GenerateKey generates a public and private key pair.
 func GenerateKey(reader io.Reader) (*PrivateKey, error) {
-	k, err := bls12.RandFieldElement(reader)
+	k, err := bls12.RandFr(reader)
 	if err != nil {
 		return nil, err
 	}
@@ -60,5 +63,20 @@ func GenerateKey(reader io.Reader) (*PrivateKey, error) {
 
 // Sign signs a hash using the private key, priv.
 func Sign(priv *PrivateKey, hash []byte) []byte {
-	return new(bls12.G2Point).ScalarMult(new(bls12.G2Point).HashToPoint(hash), priv.Secret).Marshal()
+	return new(bls12.G2Point).ScalarMultCTFr(new(bls12.G2Point).HashToPoint(hash), priv.Secret).Marshal()
+}
+
+// Verify verifies the marshaled signature of hash, sig, using the public key,
+// pub. Its return value records whether the signature is valid.
+func Verify(hash []byte, sig []byte, pubKey *PublicKey) bool {
+	s := new(bls12.G2Point)
+	if err := s.Unmarshal(sig); err != nil {
+		return false
+	}
+
+	// e(g1, sig) = e(pk, H(m))
+	return bls12.PairingCheck(
+		[]*bls12.G1Point{new(bls12.G1Point).Neg(g1Gen), &pubKey.G1Point},
+		[]*bls12.G2Point{s, new(bls12.G2Point).HashToPoint(hash)},
+	)
 }
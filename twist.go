@@ -5,29 +5,98 @@ import (
 )
 
 var (
+	// fq2TwistB is the coefficient of the twist: y²=x³+4(u+1).
+	fq2TwistB = &fq2{c0: *fqCurveB, c1: *fqCurveB}
+
+	// fq2TwistB3 is 3b, used by the complete addition formulas.
+	fq2TwistB3 = &fq2{c0: *fqCurveB3, c1: *fqCurveB3}
+
+	// fq2IsoA and fq2IsoB are the coefficients of E2´: y²=x³+A´x+B´, the curve
+	// that is 3-isogenous to E2. A´ = 240u and B´ = 1012(1+u).
+	fq2IsoA = &fq2{
+		c1: fq{0xe53a000003135242, 0x1080c0fdef80285, 0xe7889edbe340f6bd, 0xb51375126310601, 0x2d6985717c744ab, 0x1220b4e979ea5467},
+	}
+	fq2IsoB = &fq2{
+		c0: fq{0x22ea00000cf89db2, 0x6ec832df71380aa4, 0x6e1b94403db5a66e, 0x75bf3c53a79473ba, 0x3dd3a569412c0a34, 0x125cdb5e74dc4fd1},
+		c1: fq{0x22ea00000cf89db2, 0x6ec832df71380aa4, 0x6e1b94403db5a66e, 0x75bf3c53a79473ba, 0x3dd3a569412c0a34, 0x125cdb5e74dc4fd1},
+	}
+
+	// fq2SWUZ is the non-square Z = -(2+u) used by the simplified SWU map for E2´.
+	fq2SWUZ = &fq2{
+		c0: fq{0x87ebfffffff9555c, 0x656fffe5da8ffffa, 0xfd0749345d33ad2, 0xd951e663066576f4, 0xde291a3d41e980d3, 0x815664c7dfe040d},
+		c1: fq{0x43f5fffffffcaaae, 0x32b7fff2ed47fffd, 0x7e83a49a2e99d69, 0xeca8f3318332bb7a, 0xef148d1ea0f4c069, 0x40ab3263eff0206},
+	}
+
+	// fq2SWUC2 is sqrt(-1) = u.
+	fq2SWUC2 = &fq2{
+		c1: fq{0x760900000002fffd, 0xebf4000bc40c0002, 0x5f48985753c758ba, 0x77ce585370525745, 0x5c071a97a256ec6d, 0x15f65ec3fa80e493},
+	}
+
+	// fq2SWUC3 is sqrt(c2).
+	fq2SWUC3 = &fq2{
+		// 2973677408986561043442465346520108879172042883009249989176415018091420807192182638567116318576472649347015917690530
+		c0: fq{0x3e2f585da55c9ad1, 0x4294213d86c18183, 0x382844c88b623732, 0x92ad2afd19103e18, 0x1d794e4fac7cf0b9, 0xbd592fc7d825ec8},
+		// 1028732146235106349975324479215795277384839936929757896155643118032610843298655225875571310552543014690878354869257
+		c1: fq{0x7bcfa7a25aa30fda, 0xdc17dec12a927e7c, 0x2f088dd86b4ebef1, 0xd1ca2087da74d4a7, 0x2da2596696cebc1d, 0xe2b7eedbbfd87d2},
+	}
+
+	// fq2SWUC4 is sqrt(Z³ / c3).
+	fq2SWUC4 = &fq2{
+		// 1015919005498129635886032702454337503112659152043614931979881174103627376789972962005013361970813319613593700736144
+		c0: fq{0x5e514668ac736d2, 0x9089b4d6b84f3ea5, 0x603c384c224a8b32, 0xf3257909536afea6, 0x5c5cdbabae656d81, 0x75bfa0863c987e9},
+		// 1244231661155348484223428017511856347821538750986231559855759541903146219579071812422210818684355842447591283616181
+		c1: fq{0x338d9bfe08087330, 0x7b8e48b2bd83cefe, 0x530dad5d306b5be7, 0x5a4d7e8e6c408b6d, 0x6258f7a6232cab9b, 0xb985811cce14db5},
+	}
+
+	// fq2SWUC5 is sqrt(Z³ / (c2 c3)).
+	fq2SWUC5 = &fq2{
+		// 2364656849202240506627992632442075854991333434964021261821139393069706628902643788776727457290883891810009113172714
+		c0: fq{0xb8640a067f5c429f, 0xcfd425f04b4dc505, 0x72d7e2ebb535cb1, 0xd947b5f9d2b4754d, 0x46a7142740774afb, 0xc31864c32fb3b7e},
+		// 1646015993121829755895883253076789309308090876275172350194834453434199515639474951814226234213676147507404483718679
+		c1: fq{0x718fdad24ee1d90f, 0xa58c025bed8276af, 0xc3a10230ab7976f, 0xf0c54df5c8f275e1, 0x4ec2478c28baf465, 0x1129373a90c508e6},
+	}
+
+	// psiX and psiY are the values by which to multiply the conjugated
+	// coordinates to calculate psi, 1/(1+u)^((q-1)/3) and 1/(1+u)^((q-1)/2).
+	psiX = &fq2{
+		// 4002409555221667392624310435006688643935503118305586438271171395842971157480381377015405980053539358417135540939437
+		c1: fq{0x890dc9e4867545c3, 0x2af322533285a5d5, 0x50880866309b7e2c, 0xa20d1b8c7e881024, 0x14e4f04fe2db9068, 0x14e56d3f1564853a},
+	}
+	psiY = &fq2{
+		// 2973677408986561043442465346520108879172042883009249989176415018091420807192182638567116318576472649347015917690530
+		c0: fq{0x3e2f585da55c9ad1, 0x4294213d86c18183, 0x382844c88b623732, 0x92ad2afd19103e18, 0x1d794e4fac7cf0b9, 0xbd592fc7d825ec8},
+		// 1028732146235106349975324479215795277384839936929757896155643118032610843298655225875571310552543014690878354869257
+		c1: fq{0x7bcfa7a25aa30fda, 0xdc17dec12a927e7c, 0x2f088dd86b4ebef1, 0xd1ca2087da74d4a7, 0x2da2596696cebc1d, 0xe2b7eedbbfd87d2},
+	}
+
+	// psi2X is the value by which to multiply x to calculate psi², a primitive
+	// cube root of unity in Fq.
+	// 4002409555221667392624310435006688643935503118305586438271171395842971157480381377015405980053539358417135540939436
+	psi2X = &fq{0xcd03c9e48671f071, 0x5dab22461fcda5d2, 0x587042afd3851b95, 0x8eb60ebe01bacb9e, 0x3f97d6e83d050d2, 0x18f0206554638741}
+
 	// Values taken from the execution of https://eprint.iacr.org/2019/403.pdf - A The isogeny maps.
 	iso3XNum = []*fq2{
 		&fq2{
 			// 889424345604814976315064405719089812568196182208668418962679585805340366775741747653930584250892369786198727235542
-			c0: fq{},
+			c0: fq{0x47f671c71ce05e62, 0x6dd57071206393e, 0x7c80cd2af3fd71a2, 0x48103ea9e6cd062, 0xc54516acc8d037f6, 0x13808f550920ea41},
 			// 889424345604814976315064405719089812568196182208668418962679585805340366775741747653930584250892369786198727235542
-			c1: fq{},
+			c1: fq{0x47f671c71ce05e62, 0x6dd57071206393e, 0x7c80cd2af3fd71a2, 0x48103ea9e6cd062, 0xc54516acc8d037f6, 0x13808f550920ea41},
 		},
 		&fq2{
 			// 0
 			c0: fq{},
 			// 2668273036814444928945193217157269437704588546626005256888038757416021100327225242961791752752677109358596181706522
-			c1: fq{},
+			c1: fq{0x5fe55555554c71d0, 0x873fffdd236aaaa3, 0x6a6b4619b26ef918, 0x21c2888408874945, 0x2836cda7028cabc5, 0xac73310a7fd5abd},
 		},
 		&fq2{
 			// 2668273036814444928945193217157269437704588546626005256888038757416021100327225242961791752752677109358596181706526
-			c0: fq{},
+			c0: fq{0xa0c5555555971c3, 0xdb0c00101f9eaaae, 0xb1fb2f941d797997, 0xd3960742ef416e1c, 0xb70040e2c20556f4, 0x149d7861e581393b},
 			// 1334136518407222464472596608578634718852294273313002628444019378708010550163612621480895876376338554679298090853261
-			c1: fq{},
+			c1: fq{0xaff2aaaaaaa638e8, 0x439fffee91b55551, 0xb535a30cd9377c8c, 0x90e144420443a4a2, 0x941b66d3814655e2, 0x563998853fead5e},
 		},
 		&fq2{
 			// 3557697382419259905260257622876359250272784728834673675850718343221361467102966990615722337003569479144794908942033
-			c0: fq{},
+			c0: fq{0x40aac71c71c725ed, 0x190955557a84e38e, 0xd817050a8f41abc3, 0xd86485d4c87f6fb1, 0x696eb479f885d059, 0x198e1a74328002d2},
 			// 0
 			c1: fq{},
 		},
@@ -37,17 +106,17 @@ var (
 		&fq2{
 			c0: fq{},
 			// 4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559715
-			c1: fq{},
+			c1: fq{0x1f3affffff13ab97, 0xf25bfc611da3ff3e, 0xca3757cb3819b208, 0x3e6427366f8cec18, 0x3977bc86095b089, 0x4f69db13f39a952},
 		},
 		&fq2{
 			// 12
-			c0: fq{},
+			c0: fq{0x447600000027552e, 0xdcb8009a43480020, 0x6f7ee9ce4a6e8b59, 0xb10330b7c0a95bc6, 0x6140b1fcfb1e54b7, 0x381be097f0bb4e1},
 			// 4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559775
-			c1: fq{},
+			c1: fq{0x7588ffffffd8557d, 0x41f3ff646e0bffdf, 0xf7b1e8d2ac426aca, 0xb3741acd32dbb6f8, 0xe9daf5b9482d581f, 0x167f53e0ba7431b8},
 		},
 		&fq2{
 			// 1
-			c0: fq{},
+			c0: fq{0x760900000002fffd, 0xebf4000bc40c0002, 0x5f48985753c758ba, 0x77ce585370525745, 0x5c071a97a256ec6d, 0x15f65ec3fa80e493},
 			c1: fq{},
 		},
 	}
@@ -55,24 +124,24 @@ var (
 	iso3YNum = []*fq2{
 		&fq2{
 			// 3261222600550988246488569487636662646083386001431784202863158481286248011511053074731078808919938689216061999863558
-			c0: fq{},
+			c0: fq{0x96d8f684bdfc77be, 0xb530e4f43b66d0e2, 0x184a88ff379652fd, 0x57cb23ecfae804e1, 0xfd2e39eada3eba9, 0x8c8055e31c5d5c3},
 			// 3261222600550988246488569487636662646083386001431784202863158481286248011511053074731078808919938689216061999863558
-			c1: fq{},
+			c1: fq{0x96d8f684bdfc77be, 0xb530e4f43b66d0e2, 0x184a88ff379652fd, 0x57cb23ecfae804e1, 0xfd2e39eada3eba9, 0x8c8055e31c5d5c3},
 		},
 		&fq2{
 			c0: fq{},
 			// 889424345604814976315064405719089812568196182208668418962679585805340366775741747653930584250892369786198727235518
-			c1: fq{},
+			c1: fq{0xbf0a71c71c91b406, 0x4d6d55d28b7638fd, 0x9d82f98e5f205aee, 0xa27aa27b1d1a18d5, 0x2c3b2b2d2938e86, 0xc7d13420b09807f},
 		},
 		&fq2{
 			// 2668273036814444928945193217157269437704588546626005256888038757416021100327225242961791752752677109358596181706524
-			c0: fq{},
+			c0: fq{0xd7f9555555531c74, 0x21cffff748daaaa8, 0x5a9ad1866c9bbe46, 0x4870a2210221d251, 0x4a0db369c0a32af1, 0x2b1ccc429ff56af},
 			// 1334136518407222464472596608578634718852294273313002628444019378708010550163612621480895876376338554679298090853263
-			c1: fq{},
+			c1: fq{0xe205aaaaaaac8e37, 0xfcdc000768795556, 0xc96011a8a1537dd, 0x1c06a963f163406e, 0x10df44c82a881e6, 0x174f45260f808feb},
 		},
 		&fq2{
 			// 2816510427748580758331037284777117739799287910327449993381818688383577828123182200904113516794492504322962636245776
-			c0: fq{},
+			c0: fq{0xa470bda12f67f35c, 0xc0fe38e23327b425, 0xc9d3d0f2c6f0678d, 0x1c55c9935b5a982e, 0x27f6c0e2f0746764, 0x117c5e6e28aa9054},
 			c1: fq{},
 		},
 	}
@@ -80,24 +149,24 @@ var (
 	iso3YDen = []*fq2{
 		&fq2{
 			// 4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559355
-			c0: fq{},
+			c0: fq{0x162fffffa765adf, 0x8f7bea480083fb75, 0x561b3c2259e93611, 0x11e19fc1a9c875d5, 0xca713efc00367660, 0x3c6a03d41da1151},
 			// 4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559355
-			c1: fq{},
+			c1: fq{0x162fffffa765adf, 0x8f7bea480083fb75, 0x561b3c2259e93611, 0x11e19fc1a9c875d5, 0xca713efc00367660, 0x3c6a03d41da1151},
 		},
 		&fq2{
 			c0: fq{},
 			// 4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559571
-			c1: fq{},
+			c1: fq{0x5db0fffffd3b02c5, 0xd713f52358ebfdba, 0x5ea60761a84d161a, 0xbb2c75a34ea6c44a, 0xac6735921c1119b, 0xee3d913bdacfbf6},
 		},
 		&fq2{
 			// 18
-			c0: fq{},
+			c0: fq{0x66b10000003affc5, 0xcb1400e764ec0030, 0xa73e5eb56fa5d106, 0x8984c913a0fe09a9, 0x11e10afb78ad7f13, 0x5429d0e3e918f52},
 			// 4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559769
-			c1: fq{},
+			c1: fq{0x534dffffffc4aae6, 0x5397ff174c67ffcf, 0xbff273eb870b251d, 0xdaf2827152870915, 0x393a9cbaca9e2dc3, 0x14be74dbfaee5748},
 		},
 		&fq2{
 			// 1
-			c0: fq{},
+			c0: fq{0x760900000002fffd, 0xebf4000bc40c0002, 0x5f48985753c758ba, 0x77ce585370525745, 0x5c071a97a256ec6d, 0x15f65ec3fa80e493},
 			c1: fq{},
 		},
 	}
@@ -138,6 +207,60 @@ func (a *twistPoint) IsInfinity() bool {
 	return a.z == fq2{}
 }
 
+// IsOnCurve reports whether a satisfies the twist equation,
+// Y² = X³ + 4(u+1)Z⁶ in jacobian coordinates.
+func (a *twistPoint) IsOnCurve() bool {
+	if a.IsInfinity() {
+		return true
+	}
+
+	lhs, rhs, t := new(fq2), new(fq2), new(fq2)
+	lhs.Sqr(&a.y)
+	rhs.Sqr(&a.x)
+	rhs.Mul(rhs, &a.x)
+	t.Sqr(&a.z)
+	t.Mul(t, &a.z)
+	t.Sqr(t)
+	t.Mul(t, fq2TwistB)
+	rhs.Add(rhs, t)
+
+	return *lhs == *rhs
+}
+
+// IsInSubgroup reports whether a is in the subgroup of order r. a is in G2 if
+// and only if psi(a) = [x]a.
+// See https://eprint.iacr.org/2021/1130.pdf.
+func (a *twistPoint) IsInSubgroup() bool {
+	// x = -u
+	p := new(twistPoint).mulByU(a)
+	p.Neg(p)
+	return new(twistPoint).Psi(a).isEquivalent(p)
+}
+
+// isEquivalent reports whether a and b represent the same point, regardless of
+// their jacobian coordinates.
+func (a *twistPoint) isEquivalent(b *twistPoint) bool {
+	if a.IsInfinity() || b.IsInfinity() {
+		return a.IsInfinity() == b.IsInfinity()
+	}
+
+	// X1 Z2² = X2 Z1² and Y1 Z2³ = Y2 Z1³
+	z1z1, z2z2, t0, t1 := new(fq2), new(fq2), new(fq2), new(fq2)
+	z1z1.Sqr(&a.z)
+	z2z2.Sqr(&b.z)
+	t0.Mul(&a.x, z2z2)
+	t1.Mul(&b.x, z1z1)
+	if *t0 != *t1 {
+		return false
+	}
+	t0.Mul(&a.y, z2z2)
+	t0.Mul(t0, &b.z)
+	t1.Mul(&b.y, z1z1)
+	t1.Mul(t1, &a.z)
+
+	return *t0 == *t1
+}
+
 // Add sets c to the sum a+b and returns c.
 func (c *twistPoint) Add(a, b *twistPoint) *twistPoint {
 	if a.IsInfinity() {
@@ -147,11 +270,6 @@ func (c *twistPoint) Add(a, b *twistPoint) *twistPoint {
 		return c.Set(a)
 	}
 
-	// faster than Add
-	if a.Equal(b) {
-		return c.Double(a)
-	}
-
 	// See https://hyperelliptic.org/EFD/g1p/auto-shortw-jacobian-0.html#addition-add-2007-bl
 	z1z1 := new(fq2).Sqr(&a.z)
 	z2z2 := new(fq2).Sqr(&b.z)
@@ -165,10 +283,19 @@ func (c *twistPoint) Add(a, b *twistPoint) *twistPoint {
 	s2.Mul(s2, z1z1)
 
 	h := new(fq2).Sub(u2, u1)
+	r := new(fq2).Sub(s2, s1)
+
+	// a and b have the same x coordinate: a = ±b.
+	if *h == (fq2{}) {
+		if *r == (fq2{}) {
+			return c.Double(a)
+		}
+		return c.Set(&twistPoint{})
+	}
+
 	i := new(fq2).Add(h, h)
 	i.Sqr(i)
 	j := new(fq2).Mul(h, i)
-	r := new(fq2).Sub(s2, s1)
 	r.Add(r, r)
 	v := new(fq2).Mul(u1, i)
 
@@ -193,6 +320,61 @@ func (c *twistPoint) Add(a, b *twistPoint) *twistPoint {
 	return c.Set(p)
 }
 
+// AddMixed sets c to the sum a+b and returns c.
+// See https://hyperelliptic.org/EFD/g1p/auto-shortw-jacobian-0.html#addition-madd-2007-bl
+func (c *twistPoint) AddMixed(a *twistPoint, b *twistPointAffine) *twistPoint {
+	if a.IsInfinity() {
+		c.x, c.y = b.x, b.y
+		c.z.SetOne()
+		return c
+	}
+
+	z1z1, u2, s2 := new(fq2), new(fq2), new(fq2)
+	z1z1.Sqr(&a.z)
+	u2.Mul(&b.x, z1z1)
+	s2.Mul(&b.y, &a.z)
+	s2.Mul(s2, z1z1)
+
+	h, r := new(fq2), new(fq2)
+	h.Sub(u2, &a.x)
+	r.Sub(s2, &a.y)
+
+	// a and b have the same x coordinate: a = ±b.
+	if *h == (fq2{}) {
+		if *r == (fq2{}) {
+			return c.Double(a)
+		}
+		return c.Set(&twistPoint{})
+	}
+
+	hh, i, j, v := new(fq2), new(fq2), new(fq2), new(fq2)
+	hh.Sqr(h)
+	i.Add(hh, hh)
+	i.Add(i, i)
+	j.Mul(h, i)
+	r.Add(r, r)
+	v.Mul(&a.x, i)
+
+	p, t0 := new(twistPoint), new(fq2)
+	p.x.Sqr(r)
+	p.x.Sub(&p.x, j)
+	p.x.Sub(&p.x, v)
+	p.x.Sub(&p.x, v)
+
+	t0.Sub(v, &p.x)
+	p.y.Mul(r, t0)
+	t0.Mul(&a.y, j)
+	t0.Add(t0, t0)
+	p.y.Sub(&p.y, t0)
+
+	p.z.Add(&a.z, h)
+	p.z.Sqr(&p.z)
+	p.z.Sub(&p.z, z1z1)
+	p.z.Sub(&p.z, hh)
+
+	return c.Set(p)
+}
+
 // See http://www.hyperelliptic.org/EFD/g1p/auto-shortw-jacobian-0.html#doubling-dbl-2009-l
 func (c *twistPoint) Double(a *twistPoint) *twistPoint {
 	d, e, f, g, h, i := new(fq2), new(fq2), new(fq2), new(fq2), new(fq2), new(fq2)
@@ -235,12 +417,206 @@ func (c *twistPoint) ScalarMult(a *twistPoint, b *big.Int) *twistPoint {
 	return c.Set(p)
 }
 
+// Neg sets c to -a and returns c.
+func (c *twistPoint) Neg(a *twistPoint) *twistPoint {
+	c.Set(a)
+	c.y.Neg(&c.y)
+	return c
+}
+
+// mulByU sets c to the product of a and the absolute value of the curve
+// parameter and returns c.
+func (c *twistPoint) mulByU(a *twistPoint) *twistPoint {
+	p := new(twistPoint).Set(a)
+	for i := uArrLen - 2; i >= 0; i-- {
+		p.Double(p)
+		if uArr[i] == 1 {
+			p.Add(p, a)
+		}
+	}
+
+	return c.Set(p)
+}
+
+// Psi sets c to the untwist-Frobenius-twist endomorphism of a and returns c.
+// See https://www.rfc-editor.org/rfc/rfc9380.html#appendix-G.3.
+func (c *twistPoint) Psi(a *twistPoint) *twistPoint {
+	c.x.Frobenius(&a.x, 1)
+	c.x.Mul(&c.x, psiX)
+	c.y.Frobenius(&a.y, 1)
+	c.y.Mul(&c.y, psiY)
+	c.z.Frobenius(&a.z, 1)
+	c.t.Frobenius(&a.t, 1)
+	return c
+}
+
+// Psi2 sets c to psi(psi(a)) and returns c.
+func (c *twistPoint) Psi2(a *twistPoint) *twistPoint {
+	fqMul(&c.x.c0, &a.x.c0, psi2X)
+	fqMul(&c.x.c1, &a.x.c1, psi2X)
+	c.y.Neg(&a.y)
+	c.z.Set(&a.z)
+	c.t.Set(&a.t)
+	return c
+}
+
+// ScalarMultGLS sets c to k*a and returns c. a must be in G2 since
+// ScalarMultGLS splits k in four parts of a quarter of the size with the
+// endomorphism psi, which acts on G2 as the multiplication by -x, and computes
+// the four parts at once with interleaved width-wnafWidth non-adjacent forms.
+// See https://eprint.iacr.org/2008/194.pdf.
+func (c *twistPoint) ScalarMultGLS(a *twistPoint, k *[scalarWords]uint64) *twistPoint {
+	tables := twistGLSTables(a)
+
+	return c.interleavedWNAF(tables[:], glsNAFs(k))
+}
+
+// DoubleScalarMultGLS sets c to ka*p + kb*q and returns c. p and q must be in G2.
+// DoubleScalarMultGLS splits both scalars as in ScalarMultGLS and shares the
+// doublings of the eight parts.
+func (c *twistPoint) DoubleScalarMultGLS(ka *[scalarWords]uint64, p *twistPoint, kb *[scalarWords]uint64, q *twistPoint) *twistPoint {
+	tp, tq := twistGLSTables(p), twistGLSTables(q)
+
+	return c.interleavedWNAF(append(tp[:], tq[:]...), append(glsNAFs(ka), glsNAFs(kb)...))
+}
+
+// twistGLSTables returns the odd multiples (2i+1)*x^j*a = (-psi)^j((2i+1)*a)
+// used by the GLS method.
+func twistGLSTables(a *twistPoint) (tables [4]*[wnafTableSize]twistPoint) {
+	for j := range tables {
+		tables[j] = new([wnafTableSize]twistPoint)
+	}
+	tables[0][0].Set(a)
+	double := new(twistPoint).Double(a)
+	for i := 1; i < wnafTableSize; i++ {
+		tables[0][i].Add(&tables[0][i-1], double)
+	}
+	for j := 1; j < len(tables); j++ {
+		for i := range tables[j] {
+			tables[j][i].Psi(&tables[j-1][i]).Neg(&tables[j][i])
+		}
+	}
+	return tables
+}
+
+// glsNAFs returns the non-adjacent forms of the four parts of k.
+func glsNAFs(k *[scalarWords]uint64) [][]int8 {
+	ks := glsDecompose(k)
+	nafs := make([][]int8, len(ks))
+	for j := range nafs {
+		nafs[j] = wnaf([scalarWords]uint64{ks[j]}, wnafWidth)
+	}
+	return nafs
+}
+
+// interleavedWNAF sets c to the sum of nafs[j]*tables[j][0] and returns c,
+// where tables[j] holds the odd multiples of a point. The non-adjacent forms
+// share a single chain of doublings.
+// See https://link.springer.com/chapter/10.1007/978-3-540-30564-4_8.
+func (c *twistPoint) interleavedWNAF(tables []*[wnafTableSize]twistPoint, nafs [][]int8) *twistPoint {
+	n := 0
+	for _, naf := range nafs {
+		if len(naf) > n {
+			n = len(naf)
+		}
+	}
+
+	p, t := new(twistPoint), new(twistPoint)
+	for i := n - 1; i >= 0; i-- {
+		p.Double(p)
+		for j, naf := range nafs {
+			if i >= len(naf) || naf[i] == 0 {
+				continue
+			}
+			if d := naf[i]; d > 0 {
+				p.Add(p, &tables[j][d/2])
+			} else {
+				p.Add(p, t.Neg(&tables[j][-d/2]))
+			}
+		}
+	}
+
+	return c.Set(p)
+}
+
+// ScalarMultCT sets c to k*a and returns c, in constant time with respect to
+// k. a must be in G2 since k is split in four parts with
+// the endomorphism psi as in ScalarMultGLS. ScalarMultCT uses a fixed window of
+// ctWindowBits over the four parts, constant-time table lookups and complete
+// addition formulas in homogeneous projective coordinates.
+func (c *twistPoint) ScalarMultCT(a *twistPoint, k *[scalarWords]uint64) *twistPoint {
+	tables := twistGLSTablesCT(a)
+
+	return c.fixedWindowCT(tables[:], glsParts(k))
+}
+
+// DoubleScalarMultCT sets c to ka*p + kb*q and returns c, in constant time with
+// respect to ka and kb. p and q must be in G2. DoubleScalarMultCT splits both
+// scalars as in ScalarMultCT and shares the doublings of the eight parts.
+func (c *twistPoint) DoubleScalarMultCT(ka *[scalarWords]uint64, p *twistPoint, kb *[scalarWords]uint64, q *twistPoint) *twistPoint {
+	tp, tq := twistGLSTablesCT(p), twistGLSTablesCT(q)
+
+	return c.fixedWindowCT(append(tp[:], tq[:]...), append(glsParts(ka), glsParts(kb)...))
+}
+
+// twistGLSTablesCT returns the multiples i*x^j*a = (-psi)^j(i*a) used by the
+// constant-time GLS method.
+func twistGLSTablesCT(a *twistPoint) (tables [4]*[ctWindowSize]twistPointProj) {
+	for j := range tables {
+		tables[j] = new([ctWindowSize]twistPointProj)
+	}
+	tables[0][0].SetInfinity()
+	tables[0][1].FromJacobian(a)
+	for i := 2; i < ctWindowSize; i++ {
+		tables[0][i].Add(&tables[0][i-1], &tables[0][1])
+	}
+	for j := 1; j < len(tables); j++ {
+		for i := range tables[j] {
+			tables[j][i].Psi(&tables[j-1][i])
+			tables[j][i].y.Neg(&tables[j][i].y)
+		}
+	}
+	return tables
+}
+
+// glsParts returns the four parts of k, in constant time.
+func glsParts(k *[scalarWords]uint64) []*[scalarWords]uint64 {
+	ks := glsDecompose(k)
+	parts := make([]*[scalarWords]uint64, len(ks))
+	for j := range parts {
+		parts[j] = &[scalarWords]uint64{ks[j]}
+	}
+	return parts
+}
+
+// fixedWindowCT sets c to the sum of ks[j]*tables[j][1] and returns c, in
+// constant time with respect to the scalars, where tables[j][i] is i times a
+// point and the scalars are smaller than x. The scalars share a single chain of
+// doublings.
+func (c *twistPoint) fixedWindowCT(tables []*[ctWindowSize]twistPointProj, ks []*[scalarWords]uint64) *twistPoint {
+	p, t := new(twistPointProj).SetInfinity(), new(twistPointProj)
+	for i := glsWindows - 1; i >= 0; i-- {
+		for j := 0; j < ctWindowBits; j++ {
+			p.Double(p)
+		}
+		for j, k := range ks {
+			t.Lookup(tables[j], ctWindow(k, i))
+			p.Add(p, t)
+		}
+	}
+
+	return p.ToJacobian(c)
+}
+
 // ToAffine sets a to its affine value and returns a.
 // See https://www.sciencedirect.com/topics/computer-science/affine-coordinate - Jacobian Projective Points
 func (a *twistPoint) ToAffine() *twistPoint {
 	if (a.z.c0 == *new(fq).SetUint64(1)) && (a.z.c1 == fq{}) {
 		return a
 	}
+	if a.IsInfinity() {
+		return a
+	}
 
 	zInv, zInvSqr, zInvCube := new(fq2), new(fq2), new(fq2)
 	zInv.Inv(&a.z)
@@ -254,78 +630,798 @@ func (a *twistPoint) ToAffine() *twistPoint {
 	return a
 }
 
-// SWUMap maps a value of the finite field to a point in the twist curve.
-// The point is not guaranteed to be in a particular subgroup.
-// SWUMap implements an optimized version of the SWU map.
-// See https://eprint.iacr.org/2019/403.pdf - Section 4.4.
+// MapToCurve sets a to the image of t in the twist curve and returns a. The
+// point is not guaranteed to be in a particular subgroup.
+// See https://www.rfc-editor.org/rfc/rfc9380.html#section-6.6.3.
+func (a *twistPoint) MapToCurve(t *fq2) *twistPoint {
+	return a.iso3(a.SWUMap(t))
+}
+
+// twistPointProj is a twist point in homogeneous projective
+// coordinates, (X : Y : Z) with x = X/Z and y = Y/Z. Its addition formulas are
+// complete, so they do not branch on the input points.
+// See https://eprint.iacr.org/2015/1060.pdf.
+type twistPointProj struct {
+	x, y, z fq2
+}
+
+// SetInfinity sets c to the point at infinity, (0 : 1 : 0), and returns c.
+func (c *twistPointProj) SetInfinity() *twistPointProj {
+	c.x, c.z = fq2{}, fq2{}
+	c.y.SetOne()
+	return c
+}
+
+// FromJacobian sets c to the value of a and returns c.
+func (c *twistPointProj) FromJacobian(a *twistPoint) *twistPointProj {
+	// (X : Y : Z) = (X Z : Y : Z³)
+	isInfinity := fq2IsZero(&a.z)
+	c.x.Mul(&a.x, &a.z)
+	c.z.Sqr(&a.z)
+	c.z.Mul(&c.z, &a.z)
+	fq2CMov(&c.y, &a.y, new(fq2).SetOne(), isInfinity)
+	return c
+}
+
+// ToJacobian sets a to the value of c and returns a.
+func (c *twistPointProj) ToJacobian(a *twistPoint) *twistPoint {
+	// (X : Y : Z) = (X Z : Y Z² : Z)
+	t := new(fq2)
+	t.Sqr(&c.z)
+	a.y.Mul(&c.y, t)
+	a.x.Mul(&c.x, &c.z)
+	a.z, a.t = c.z, *t
+	return a
+}
+
+// Psi sets c to psi(a) and returns c. The endomorphism is also valid in
+// homogeneous projective coordinates since the Frobenius is a field
+// automorphism.
+func (c *twistPointProj) Psi(a *twistPointProj) *twistPointProj {
+	c.x.Frobenius(&a.x, 1)
+	c.x.Mul(&c.x, psiX)
+	c.y.Frobenius(&a.y, 1)
+	c.y.Mul(&c.y, psiY)
+	c.z.Frobenius(&a.z, 1)
+	return c
+}
+
+// Lookup sets c to table[i] and returns c, in constant time with respect to i.
+func (c *twistPointProj) Lookup(table *[ctWindowSize]twistPointProj, i uint64) *twistPointProj {
+	*c = twistPointProj{}
+	for j := range table {
+		b := ctEqual(uint64(j), i)
+		fq2CMov(&c.x, &c.x, &table[j].x, b)
+		fq2CMov(&c.y, &c.y, &table[j].y, b)
+		fq2CMov(&c.z, &c.z, &table[j].z, b)
+	}
+	return c
+}
+
+// Add sets c to the sum a+b and returns c.
+// See https://eprint.iacr.org/2015/1060.pdf - Algorithm 7.
+func (c *twistPointProj) Add(a, b *twistPointProj) *twistPointProj {
+	t0, t1, t2, t3, t4 := new(fq2), new(fq2), new(fq2), new(fq2), new(fq2)
+	x3, y3, z3 := new(fq2), new(fq2), new(fq2)
+	t0.Mul(&a.x, &b.x)
+	t1.Mul(&a.y, &b.y)
+	t2.Mul(&a.z, &b.z)
+	t3.Add(&a.x, &a.y)
+	t4.Add(&b.x, &b.y)
+	t3.Mul(t3, t4)
+	t4.Add(t0, t1)
+	t3.Sub(t3, t4)
+	t4.Add(&a.y, &a.z)
+	x3.Add(&b.y, &b.z)
+	t4.Mul(t4, x3)
+	x3.Add(t1, t2)
+	t4.Sub(t4, x3)
+	x3.Add(&a.x, &a.z)
+	y3.Add(&b.x, &b.z)
+	x3.Mul(x3, y3)
+	y3.Add(t0, t2)
+	y3.Sub(x3, y3)
+	x3.Add(t0, t0)
+	t0.Add(x3, t0)
+	t2.Mul(fq2TwistB3, t2)
+	z3.Add(t1, t2)
+	t1.Sub(t1, t2)
+	y3.Mul(fq2TwistB3, y3)
+	x3.Mul(t4, y3)
+	t2.Mul(t3, t1)
+	x3.Sub(t2, x3)
+	y3.Mul(y3, t0)
+	t1.Mul(t1, z3)
+	y3.Add(t1, y3)
+	t0.Mul(t0, t3)
+	z3.Mul(z3, t4)
+	z3.Add(z3, t0)
+	c.x, c.y, c.z = *x3, *y3, *z3
+	return c
+}
+
+// Double sets c to 2*a and returns c.
+// See https://eprint.iacr.org/2015/1060.pdf - Algorithm 9.
+func (c *twistPointProj) Double(a *twistPointProj) *twistPointProj {
+	t0, t1, t2 := new(fq2), new(fq2), new(fq2)
+	x3, y3, z3 := new(fq2), new(fq2), new(fq2)
+	t0.Sqr(&a.y)
+	z3.Add(t0, t0)
+	z3.Add(z3, z3)
+	z3.Add(z3, z3)
+	t1.Mul(&a.y, &a.z)
+	t2.Sqr(&a.z)
+	t2.Mul(fq2TwistB3, t2)
+	x3.Mul(t2, z3)
+	y3.Add(t0, t2)
+	z3.Mul(t1, z3)
+	t1.Add(t2, t2)
+	t2.Add(t1, t2)
+	t0.Sub(t0, t2)
+	y3.Mul(t0, y3)
+	y3.Add(x3, y3)
+	t1.Mul(&a.x, &a.y)
+	x3.Mul(t0, t1)
+	x3.Add(x3, x3)
+	c.x, c.y, c.z = *x3, *y3, *z3
+	return c
+}
+
+// AddMixed sets c to the sum a+b and returns c.
+// See https://eprint.iacr.org/2015/1060.pdf - Algorithm 8.
+func (c *twistPointProj) AddMixed(a *twistPointProj, b *twistPointAffine) *twistPointProj {
+	t0, t1, t2, t3, t4 := new(fq2), new(fq2), new(fq2), new(fq2), new(fq2)
+	x3, y3, z3 := new(fq2), new(fq2), new(fq2)
+	t0.Mul(&a.x, &b.x)
+	t1.Mul(&a.y, &b.y)
+	t3.Add(&b.x, &b.y)
+	t4.Add(&a.x, &a.y)
+	t3.Mul(t3, t4)
+	t4.Add(t0, t1)
+	t3.Sub(t3, t4)
+	t4.Mul(&b.y, &a.z)
+	t4.Add(t4, &a.y)
+	y3.Mul(&b.x, &a.z)
+	y3.Add(y3, &a.x)
+	x3.Add(t0, t0)
+	t0.Add(x3, t0)
+	t2.Mul(fq2TwistB3, &a.z)
+	z3.Add(t1, t2)
+	t1.Sub(t1, t2)
+	y3.Mul(fq2TwistB3, y3)
+	x3.Mul(t4, y3)
+	t2.Mul(t3, t1)
+	x3.Sub(t2, x3)
+	y3.Mul(y3, t0)
+	t1.Mul(t1, z3)
+	y3.Add(t1, y3)
+	t0.Mul(t0, t3)
+	z3.Mul(z3, t4)
+	z3.Add(z3, t0)
+	c.x, c.y, c.z = *x3, *y3, *z3
+	return c
+}
+
+// CMov sets c to b if v is 1 and to a if v is 0 and returns c, in constant
+// time.
+func (c *twistPointProj) CMov(a, b *twistPointProj, v uint64) *twistPointProj {
+	fq2CMov(&c.x, &a.x, &b.x, v)
+	fq2CMov(&c.y, &a.y, &b.y, v)
+	fq2CMov(&c.z, &a.z, &b.z, v)
+	return c
+}
+
+// twistPointAffine is a twist point in affine coordinates, (x, y). It cannot
+// represent the point at infinity.
+type twistPointAffine struct {
+	x, y fq2
+}
+
+// Lookup sets c to row[i-1] and returns c, in constant time with respect to i.
+// c is set to (0, 0) if i is 0.
+func (c *twistPointAffine) Lookup(row *[ctWindowSize - 1]twistPointAffine, i uint64) *twistPointAffine {
+	*c = twistPointAffine{}
+	for j := range row {
+		b := ctEqual(uint64(j+1), i)
+		fq2CMov(&c.x, &c.x, &row[j].x, b)
+		fq2CMov(&c.y, &c.y, &row[j].y, b)
+	}
+	return c
+}
+
+// twistFixedBase holds the multiples j*16^i*a of a fixed point a, for all the
+// windows i of a scalar and all the non-zero window values j, in affine
+// coordinates. The product of a scalar and a is then the sum of one entry per
+// window, without doublings.
+type twistFixedBase struct {
+	table      [ctWindows][ctWindowSize - 1]twistPointAffine
+	isInfinity bool
+}
+
+// Init sets t to the table of a and returns t. a must be in G2.
+func (t *twistFixedBase) Init(a *twistPoint) *twistFixedBase {
+	t.isInfinity = a.IsInfinity()
+	if t.isInfinity {
+		return t
+	}
+
+	// Since r is prime and j*16^i is never a multiple of r, none of the
+	// multiples is the point at infinity.
+	var proj [ctWindows][ctWindowSize - 1]twistPointProj
+	base := new(twistPointProj).FromJacobian(a)
+	for i := range proj {
+		proj[i][0] = *base
+		for j := 1; j < ctWindowSize-1; j++ {
+			proj[i][j].Add(&proj[i][j-1], base)
+		}
+		base.Add(&proj[i][ctWindowSize-2], base)
+	}
+
+	// Montgomery's trick: a single inversion for all the z coordinates.
+	var acc [ctWindows * (ctWindowSize - 1)]fq2
+	prod := *new(fq2).SetOne()
+	for i := range proj {
+		for j := range proj[i] {
+			acc[i*(ctWindowSize-1)+j] = prod
+			prod.Mul(&prod, &proj[i][j].z)
+		}
+	}
+	inv := new(fq2).Inv(&prod)
+	for i := len(proj) - 1; i >= 0; i-- {
+		for j := len(proj[i]) - 1; j >= 0; j-- {
+			zInv := new(fq2)
+			zInv.Mul(inv, &acc[i*(ctWindowSize-1)+j])
+			inv.Mul(inv, &proj[i][j].z)
+			t.table[i][j].x.Mul(&proj[i][j].x, zInv)
+			t.table[i][j].y.Mul(&proj[i][j].y, zInv)
+		}
+	}
+
+	return t
+}
+
+// ScalarMultFixedBase sets c to k*a, where a is the point of the table t, and
+// returns c, in constant time with respect to k.
+func (c *twistPoint) ScalarMultFixedBase(t *twistFixedBase, k *[scalarWords]uint64) *twistPoint {
+	if t.isInfinity {
+		return c.Set(&twistPoint{})
+	}
+
+	p, sum, e := new(twistPointProj).SetInfinity(), new(twistPointProj), new(twistPointAffine)
+	for i := range t.table {
+		w := ctWindow(k, i)
+		e.Lookup(&t.table[i], w)
+		sum.AddMixed(p, e)
+		p.CMov(p, sum, 1^ctEqual(w, 0))
+	}
+
+	return p.ToJacobian(c)
+}
+
+// MultiExp sets c to the sum of scalars[i]*points[i] and returns c. The points
+// must be in G2 and have the same length as the scalars. MultiExp uses the
+// bucket method with signed digits and affine bucket additions that share their
+// field inversions, and spreads the windows across workers goroutines.
+// See https://eprint.iacr.org/2012/549.pdf - Section 4.
+func (c *twistPoint) MultiExp(points []*twistPoint, scalars [][scalarWords]uint64, workers int) *twistPoint {
+	// The points at infinity do not contribute to the sum.
+	finite := make([]*twistPoint, 0, len(points))
+	ks := make([][scalarWords]uint64, 0, len(scalars))
+	for i, point := range points {
+		if !point.IsInfinity() {
+			finite = append(finite, point)
+			ks = append(ks, scalars[i])
+		}
+	}
+	if len(finite) == 0 {
+		return c.Set(&twistPoint{})
+	}
+
+	affine := twistBatchToAffine(finite)
+	window := multiExpWindow(len(finite))
+	digits := multiExpDigits(ks, window)
+	sums := make([]twistPoint, len(digits))
+	parallel(len(digits), workers, func(w int) {
+		sums[w] = *twistBucketSum(affine, digits[w], window)
+	})
+
+	p := new(twistPoint)
+	for w := len(sums) - 1; w >= 0; w-- {
+		for i := uint(0); i < window; i++ {
+			p.Double(p)
+		}
+		p.Add(p, &sums[w])
+	}
+
+	return c.Set(p)
+}
+
+// twistBatchToAffine returns the affine coordinates of the points, with a
+// single field inversion. None of the points can be the point at infinity.
+func twistBatchToAffine(points []*twistPoint) []twistPointAffine {
+	// acc[i] is the product of the z coordinates of points[:i].
+	acc := make([]fq2, len(points))
+	prod := *new(fq2).SetOne()
+	for i, point := range points {
+		acc[i] = prod
+		prod.Mul(&prod, &point.z)
+	}
+	inv := new(fq2).Inv(&prod)
+
+	ret := make([]twistPointAffine, len(points))
+	zInv, zInvSqr := new(fq2), new(fq2)
+	for i := len(points) - 1; i >= 0; i-- {
+		zInv.Mul(inv, &acc[i])
+		inv.Mul(inv, &points[i].z)
+		zInvSqr.Sqr(zInv)
+		ret[i].x.Mul(&points[i].x, zInvSqr)
+		zInvSqr.Mul(zInvSqr, zInv)
+		ret[i].y.Mul(&points[i].y, zInvSqr)
+	}
+
+	return ret
+}
+
+// twistBucketSum returns the sum of digits[i]*points[i] for the digits of a
+// window of the given size.
+func twistBucketSum(points []twistPointAffine, digits []int32, window uint) *twistPoint {
+	b := newTwistBuckets(1<<(window-1), len(points) >= multiExpBatchMinPoints)
+	e := new(twistPointAffine)
+	for i, d := range digits {
+		switch {
+		case d > 0:
+			b.Add(int(d-1), &points[i])
+		case d < 0:
+			e.x = points[i].x
+			e.y.Neg(&points[i].y)
+			b.Add(int(-d-1), e)
+		}
+	}
+	b.Flush()
+
+	// sum = sum((i+1)*buckets[i]) = sum(sum(buckets[j], j >= i)).
+	running, sum := new(twistPoint), new(twistPoint)
+	for i := len(b.buckets) - 1; i >= 0; i-- {
+		if b.isSet[i] {
+			running.AddMixed(running, &b.buckets[i])
+		}
+		running.Add(running, &b.overflow[i])
+		sum.Add(sum, running)
+	}
+
+	return sum
+}
+
+// twistBuckets accumulates points in buckets. If batching is enabled, the
+// buckets are affine and the additions are delayed and processed in batches
+// that share a single field inversion. Otherwise, or if a bucket is already
+// part of the current batch, the points are added to a jacobian overflow
+// bucket instead.
+type twistBuckets struct {
+	buckets  []twistPointAffine
+	isSet    []bool
+	inBatch  []bool
+	overflow []twistPoint
+
+	batching bool
+	batch    []int
+	points   []twistPointAffine
+}
+
+// newTwistBuckets returns n empty buckets.
+func newTwistBuckets(n int, batching bool) *twistBuckets {
+	return &twistBuckets{
+		buckets:  make([]twistPointAffine, n),
+		isSet:    make([]bool, n),
+		inBatch:  make([]bool, n),
+		overflow: make([]twistPoint, n),
+		batching: batching,
+		batch:    make([]int, 0, multiExpBatchSize),
+		points:   make([]twistPointAffine, 0, multiExpBatchSize),
+	}
+}
+
+// Add adds a to the bucket i.
+func (b *twistBuckets) Add(i int, a *twistPointAffine) {
+	switch {
+	case !b.batching || b.inBatch[i]:
+		b.overflow[i].AddMixed(&b.overflow[i], a)
+	case !b.isSet[i]:
+		b.buckets[i], b.isSet[i] = *a, true
+	case b.buckets[i].x == a.x:
+		// a is either the bucket or its opposite.
+		if b.buckets[i].y == a.y {
+			b.double(i)
+		} else {
+			b.isSet[i] = false
+		}
+	default:
+		b.inBatch[i] = true
+		b.batch = append(b.batch, i)
+		b.points = append(b.points, *a)
+		if len(b.batch) == multiExpBatchSize {
+			b.flushBatch()
+		}
+	}
+}
+
+// Flush processes all the delayed additions.
+func (b *twistBuckets) Flush() {
+	if len(b.batch) > 0 {
+		b.flushBatch()
+	}
+}
+
+// flushBatch adds the points of the batch to their buckets.
+// See https://hyperelliptic.org/EFD/g1p/auto-shortw.html - Addition.
+func (b *twistBuckets) flushBatch() {
+	// inverses of x2 - x1, with Montgomery's trick.
+	dx := make([]fq2, len(b.batch))
+	acc := make([]fq2, len(b.batch))
+	prod := *new(fq2).SetOne()
+	for j, i := range b.batch {
+		dx[j].Sub(&b.points[j].x, &b.buckets[i].x)
+		acc[j] = prod
+		prod.Mul(&prod, &dx[j])
+	}
+	inv := new(fq2).Inv(&prod)
+
+	lambda, x3, t := new(fq2), new(fq2), new(fq2)
+	for j := len(b.batch) - 1; j >= 0; j-- {
+		i := b.batch[j]
+		p, q := &b.buckets[i], &b.points[j]
+
+		// lambda = (y2 - y1) / (x2 - x1)
+		t.Mul(inv, &acc[j])
+		inv.Mul(inv, &dx[j])
+		lambda.Sub(&q.y, &p.y)
+		lambda.Mul(lambda, t)
+
+		// x3 = lambda² - x1 - x2, y3 = lambda (x1 - x3) - y1
+		x3.Sqr(lambda)
+		x3.Sub(x3, &p.x)
+		x3.Sub(x3, &q.x)
+		t.Sub(&p.x, x3)
+		t.Mul(t, lambda)
+		p.y.Sub(t, &p.y)
+		p.x = *x3
+
+		b.inBatch[i] = false
+	}
+
+	b.batch, b.points = b.batch[:0], b.points[:0]
+}
+
+// double doubles the bucket i.
+// See https://hyperelliptic.org/EFD/g1p/auto-shortw.html - Doubling.
+func (b *twistBuckets) double(i int) {
+	p := &b.buckets[i]
+
+	// lambda = 3 x² / 2 y, which is defined since G2 has no point of order 2.
+	lambda, t, x3 := new(fq2), new(fq2), new(fq2)
+	t.Add(&p.y, &p.y)
+	t.Inv(t)
+	lambda.Sqr(&p.x)
+	x3.Add(lambda, lambda)
+	lambda.Add(lambda, x3)
+	lambda.Mul(lambda, t)
+
+	// x3 = lambda² - 2 x, y3 = lambda (x - x3) - y
+	x3.Sqr(lambda)
+	x3.Sub(x3, &p.x)
+	x3.Sub(x3, &p.x)
+	t.Sub(&p.x, x3)
+	t.Mul(t, lambda)
+	p.y.Sub(t, &p.y)
+	p.x = *x3
+}
+
+// Marshal converts a twist point into the uncompressed form,
+// x.c1 || x.c0 || y.c1 || y.c0, specified in
+// https://github.com/zkcrypto/pairing/tree/master/src/bls12_381#serialization.
+func (a *twistPoint) Marshal() []byte {
+	ret := make([]byte, 4*fqByteLen)
+	if a.IsInfinity() {
+		ret[0] |= pointAtInfinityMask
+		return ret
+	}
+
+	p := new(twistPoint).Set(a).ToAffine()
+	copy(ret, new(fq).MontgomeryDecode(&p.x.c1).Bytes())
+	copy(ret[fqByteLen:], new(fq).MontgomeryDecode(&p.x.c0).Bytes())
+	copy(ret[2*fqByteLen:], new(fq).MontgomeryDecode(&p.y.c1).Bytes())
+	copy(ret[3*fqByteLen:], new(fq).MontgomeryDecode(&p.y.c0).Bytes())
+
+	return ret
+}
+
+// MarshalCompressed converts a twist point into the compressed form,
+// x.c1 || x.c0 and the sign of y, specified in
+// https://github.com/zkcrypto/pairing/tree/master/src/bls12_381#serialization.
+func (a *twistPoint) MarshalCompressed() []byte {
+	ret := make([]byte, 2*fqByteLen)
+	if a.IsInfinity() {
+		ret[0] |= compressedFormMask | pointAtInfinityMask
+		return ret
+	}
+
+	p := new(twistPoint).Set(a).ToAffine()
+	copy(ret, new(fq).MontgomeryDecode(&p.x.c1).Bytes())
+	copy(ret[fqByteLen:], new(fq).MontgomeryDecode(&p.x.c0).Bytes())
+	ret[0] |= compressedFormMask
+	if fq2IsLexLargest(&p.y) {
+		ret[0] |= largestYMask
+	}
+
+	return ret
+}
+
+// Unmarshal sets a to the result of converting the output of Marshal back into
+// a twist point. On error, a is left unchanged.
+func (a *twistPoint) Unmarshal(data []byte) error {
+	if len(data) != 4*fqByteLen {
+		return ErrInvalidLength
+	}
+	if data[0]&(compressedFormMask|largestYMask) != 0 {
+		return ErrInvalidFlags
+	}
+	if data[0]&pointAtInfinityMask != 0 {
+		if !isZeroEncoding(data) {
+			return ErrInvalidInfinity
+		}
+		*a = twistPoint{}
+		return nil
+	}
+
+	var coords [4]*fq
+	for i := range coords {
+		var err error
+		coords[i], err = new(fq).SetBytes(data[i*fqByteLen : (i+1)*fqByteLen])
+		if err != nil {
+			return ErrNonCanonical
+		}
+	}
+	p := newTwistPoint(fq2{*coords[1], *coords[0]}, fq2{*coords[3], *coords[2]})
+	if !p.IsOnCurve() {
+		return ErrNotOnCurve
+	}
+	a.Set(p)
+
+	return nil
+}
+
+// UnmarshalCompressed sets a to the result of converting the output of
+// MarshalCompressed back into a twist point. On error, a is left unchanged.
+func (a *twistPoint) UnmarshalCompressed(data []byte) error {
+	if len(data) != 2*fqByteLen {
+		return ErrInvalidLength
+	}
+	if data[0]&compressedFormMask == 0 {
+		return ErrInvalidFlags
+	}
+	if data[0]&pointAtInfinityMask != 0 {
+		if !isZeroEncoding(data) {
+			return ErrInvalidInfinity
+		}
+		*a = twistPoint{}
+		return nil
+	}
+
+	buf := make([]byte, fqByteLen)
+	copy(buf, data)
+	buf[0] &^= flagsMask
+	x1, err := new(fq).SetBytes(buf)
+	if err != nil {
+		return ErrNonCanonical
+	}
+	x0, err := new(fq).SetBytes(data[fqByteLen:])
+	if err != nil {
+		return ErrNonCanonical
+	}
+	x := &fq2{*x0, *x1}
+
+	// y² = x³ + b
+	y := new(fq2).Sqr(x)
+	y.Mul(y, x)
+	y.Add(y, fq2TwistB)
+	if !y.Sqrt(y) {
+		return ErrNotOnCurve
+	}
+	if fq2IsLexLargest(y) != (data[0]&largestYMask != 0) {
+		y.Neg(y)
+	}
+	*a = *newTwistPoint(*x, *y)
+
+	return nil
+}
+
+// SWUMap sets a to the image of t in E2´, the curve that is 3-isogenous to the
+// twist, and returns a. SWUMap runs in constant time.
+// See https://www.rfc-editor.org/rfc/rfc9380.html#appendix-G.2.3.
 func (a *twistPoint) SWUMap(t *fq2) *twistPoint {
-	/*
-		n, t0, t1 := new(fq), new(fq), new(fq)
-		fqMul(t0, t, t)
-		fqMul(t1, t0, t0)
-		fqNeg(t0, t0)
-		fqAdd(t0, t0, t1)
-		fqAdd(n, t0, new(fq).SetUint64(1))
-		fqMul(n, n, fqCurveB)
-
-		d := new(fq).Set(t0) // a = -1 for performance
-		u := new(fq)
-		fqMul(u, n, n)
-		fqMul(u, u, n)
-		fqMul(t0, d, d)
-		fqMul(t1, n, t0)
-		fqNeg(t1, t1)
-		fqAdd(u, u, t1)
-		fqMul(t0, d, t0)
-		fqMul(t1, t0, fqCurveB)
-		fqAdd(u, u, t1)
-
-
-			v := new(fq).Set(t0)
-
-			if t0 == fq{} {
-				fqMul(a.x, n, d)
-				fqMul(x.y, alpha, v)
-				a.z.Set(d)
-			} else {
-				fqMul(a.x, n, d)
-				fqMul(a.y, alpha, v)
-				a.z.Set(d)
-			}
+	one := new(fq2).SetOne()
+	tv1, tv2, tv3, tv4, tv5 := new(fq2), new(fq2), new(fq2), new(fq2), new(fq2)
+	tv1.Sqr(t)
+	tv3.Mul(fq2SWUZ, tv1)
+	tv5.Sqr(tv3)
+
+	// x1 = xn / xd; the exceptional case xd = 0 is mapped to x1 = B´/(Z A´).
+	xd, x1n := new(fq2), new(fq2)
+	xd.Add(tv5, tv3)
+	x1n.Add(xd, one)
+	x1n.Mul(x1n, fq2IsoB)
+	xd.Mul(xd, fq2IsoA)
+	xd.Neg(xd)
+	tv4.Mul(fq2SWUZ, fq2IsoA)
+	fq2CMov(xd, xd, tv4, fq2IsZero(xd))
+
+	// g(x1) = gx1 / gxd
+	gxd, gx1 := new(fq2), new(fq2)
+	tv2.Sqr(xd)
+	gxd.Mul(tv2, xd)
+	tv2.Mul(fq2IsoA, tv2)
+	gx1.Sqr(x1n)
+	gx1.Add(gx1, tv2)
+	gx1.Mul(gx1, x1n)
+	tv2.Mul(fq2IsoB, gxd)
+	gx1.Add(gx1, tv2)
 
-	*/
+	// y1 = sqrt(gx1 / gxd) is one of y c2^i, i = 0..3, if g(x1) is square.
+	y := new(fq2)
+	tv4.Sqr(gxd)
+	tv2.Mul(tv4, gxd)
+	tv4.Sqr(tv4)
+	tv2.Mul(tv2, tv4)
+	tv2.Mul(tv2, gx1)
+	tv4.Sqr(tv4)
+	tv4.Mul(tv2, tv4)
+	y.Exp(tv4, qSqrMinusNineOverSixteen)
+	y.Mul(y, tv2)
+	tv4.Mul(y, fq2SWUC2)
+	tv2.Sqr(tv4)
+	tv2.Mul(tv2, gxd)
+	fq2CMov(y, y, tv4, fq2Equal(tv2, gx1))
+	tv4.Mul(y, fq2SWUC3)
+	tv2.Sqr(tv4)
+	tv2.Mul(tv2, gxd)
+	fq2CMov(y, y, tv4, fq2Equal(tv2, gx1))
+	tv4.Mul(tv4, fq2SWUC2)
+	tv2.Sqr(tv4)
+	tv2.Mul(tv2, gxd)
+	fq2CMov(y, y, tv4, fq2Equal(tv2, gx1))
+
+	// x2 = Z t² x1 and y2 = t³ y1 c for c in {c4, c4 c2, c5, c5 c2} otherwise.
+	gx2, y2 := new(fq2), new(fq2)
+	gx2.Mul(gx1, tv5)
+	gx2.Mul(gx2, tv3)
+	tv5.Mul(y, tv1)
+	tv5.Mul(tv5, t)
+	y2.Mul(tv5, fq2SWUC4)
+	tv4.Mul(y2, fq2SWUC2)
+	tv2.Sqr(tv4)
+	tv2.Mul(tv2, gxd)
+	fq2CMov(y2, y2, tv4, fq2Equal(tv2, gx2))
+	tv4.Mul(tv5, fq2SWUC5)
+	tv2.Sqr(tv4)
+	tv2.Mul(tv2, gxd)
+	fq2CMov(y2, y2, tv4, fq2Equal(tv2, gx2))
+	tv4.Mul(tv4, fq2SWUC2)
+	tv2.Sqr(tv4)
+	tv2.Mul(tv2, gxd)
+	fq2CMov(y2, y2, tv4, fq2Equal(tv2, gx2))
+
+	xn := new(fq2)
+	tv2.Sqr(y)
+	tv2.Mul(tv2, gxd)
+	isSquare := fq2Equal(tv2, gx1)
+	fq2CMov(y, y2, y, isSquare)
+	tv2.Mul(tv3, x1n)
+	fq2CMov(xn, tv2, x1n, isSquare)
+
+	// sgn0(y) = sgn0(t)
+	tv1.Neg(y)
+	fq2CMov(y, tv1, y, 1^(fq2Sgn0(t)^fq2Sgn0(y)))
+
+	// (x, y) = (xn/xd, y) in jacobian coordinates.
+	a.x.Mul(xn, xd)
+	tv2.Sqr(xd)
+	a.t.Set(tv2)
+	tv2.Mul(tv2, xd)
+	a.y.Mul(y, tv2)
+	a.z.Set(xd)
 
 	return a
 }
 
+// iso3 sets a to the image of b under the 3-isogeny map from E2´ to the twist
+// and returns a.
+// See https://www.rfc-editor.org/rfc/rfc9380.html#appendix-E.3.
 func (a *twistPoint) iso3(b *twistPoint) *twistPoint {
+	// zz[i] = z^2i
+	zz := make([]fq2, len(iso3YNum))
+	zz[0].SetOne()
+	zz[1].Sqr(&b.z)
+	for i := 2; i < len(zz); i++ {
+		zz[i].Mul(&zz[i-1], &zz[1])
+	}
+
+	// sum[i] = Σ kij x^j z^2(deg-j)
 	term := new(fq2)
 	mul := new(fq2)
 	var sum [4]fq2
 	for i, ki := range iso3K {
-		sum[i].Set(ki[0])
+		deg := len(ki) - 1
+		sum[i].SetZero()
 		mul.SetOne()
-		for _, kij := range ki[1:] {
-			mul.Mul(mul, &b.x)
+		for j, kij := range ki {
 			term.Mul(kij, mul)
+			term.Mul(term, &zz[deg-j])
 			sum[i].Add(&sum[i], term)
+			mul.Mul(mul, &b.x)
 		}
 	}
 
-	sum[1].Inv(&sum[1])
-	a.x.Mul(&sum[0], &sum[1])
-	sum[3].Inv(&sum[3])
-	term.Mul(&sum[2], &sum[3])
-	a.y.Mul(&b.y, term)
+	// x = xNum / (xDen z²) and y = y yNum / (z³ yDen).
+	xDen, yDen := new(fq2), new(fq2)
+	xDen.Mul(&sum[1], &zz[1])
+	yDen.Mul(&sum[3], &zz[1])
+	yDen.Mul(yDen, &b.z)
+
+	// (X, Y, Z) = (xNum xDen yDen², y yNum xDen³ yDen², xDen yDen)
+	t0, t1 := new(fq2), new(fq2)
+	t0.Sqr(yDen)
+	t1.Sqr(xDen)
+	t1.Mul(t1, xDen)
+	a.y.Mul(&b.y, &sum[2])
+	a.y.Mul(&a.y, t1)
+	a.y.Mul(&a.y, t0)
+	a.x.Mul(&sum[0], xDen)
+	a.x.Mul(&a.x, t0)
+	a.z.Mul(xDen, yDen)
+	a.t.Sqr(&a.z)
 
 	return a
 }
 
-// See https://eprint.iacr.org/2019/403.pdf - Section 5, Construction #5.
-func (a *twistPoint) HashToPoint(msg []byte) *twistPoint {
-	t0 := new(twistPoint).SWUMap(hp2(msg))
-	t1 := new(twistPoint).SWUMap(hp2(msg))
-	t0.iso3(t0.Add(t0, t1))
+// HashToPoint sets a to the twist point that results from the given slice of
+// bytes and domain separation tag and returns a. The point is not guaranteed
+// to be in a particular subgroup.
+// See https://www.rfc-editor.org/rfc/rfc9380.html#section-3.
+func (a *twistPoint) HashToPoint(msg, dst []byte) *twistPoint {
+	u := hashToFq2(msg, dst, 2)
+	t0 := new(twistPoint).MapToCurve(&u[0])
+	t1 := new(twistPoint).MapToCurve(&u[1])
 
-	return a
+	return a.Add(t0, t1)
+}
+
+// EncodeToPoint sets a to the twist point that results from the given slice of
+// bytes and domain separation tag and returns a. Unlike HashToPoint, only one
+// field element is mapped, so the output distribution is nonuniform. The point
+// is not guaranteed to be in a particular subgroup.
+// See https://www.rfc-editor.org/rfc/rfc9380.html#section-3.
+func (a *twistPoint) EncodeToPoint(msg, dst []byte) *twistPoint {
+	u := hashToFq2(msg, dst, 1)
+	return a.MapToCurve(&u[0])
+}
+
+// ClearCofactor sets c to h_eff*a, which is in G2 for any a on the twist, and
+// returns c. ClearCofactor uses the method of Budroni and Pintore,
+// h_eff*P = [x²-x-1]P + [x-1]psi(P) + psi²(2P).
+// See https://www.rfc-editor.org/rfc/rfc9380.html#appendix-G.3.
+func (c *twistPoint) ClearCofactor(a *twistPoint) *twistPoint {
+	// x = -u
+	t1 := new(twistPoint).mulByU(a)
+	t1.Neg(t1)
+	t2 := new(twistPoint).Psi(a)
+	t3 := new(twistPoint).Double(a)
+	t3.Psi2(t3)
+	t3.Add(t3, new(twistPoint).Neg(t2))
+	t2.Add(t1, t2)
+	t2.mulByU(t2)
+	t2.Neg(t2)
+	t3.Add(t3, t2)
+	t3.Add(t3, t1.Neg(t1))
+
+	return c.Add(t3, new(twistPoint).Neg(a))
 }
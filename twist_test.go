@@ -50,6 +50,30 @@ func TestTwistPointAdd(t *testing.T) {
 	}
 }
 
+func TestTwistPointAddSpecialCases(t *testing.T) {
+	a := new(twistPoint).HashToPoint([]byte("abc"), g2Domain)
+	b := new(twistPoint).Double(a)
+	// a in different jacobian coordinates.
+	c := new(twistPoint).Add(b, new(twistPoint).Neg(a))
+	tests := map[string]struct {
+		a, b, want *twistPoint
+	}{
+		"infinity + a":   {a: &twistPoint{}, b: a, want: a},
+		"a + infinity":   {a: a, b: &twistPoint{}, want: a},
+		"a + equivalent": {a: a, b: c, want: b},
+		"a + (-a)":       {a: a, b: new(twistPoint).Neg(c), want: &twistPoint{}},
+		"a + 2a":         {a: a, b: b, want: new(twistPoint).ScalarMult(a, big.NewInt(3))},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := new(twistPoint).Add(tc.a, tc.b)
+			if !got.isEquivalent(tc.want) {
+				t.Fatalf("expected: %v, got: %v", tc.want, got)
+			}
+		})
+	}
+}
+
 func TestTwistPointDouble(t *testing.T) {
 	tests := map[string]struct {
 		input, want twistPoint
@@ -168,6 +192,183 @@ func TestTwistPointToAffine(t *testing.T) {
 	}
 }
 
+// newAffineTwistPoint returns the twist point (x0 + x1 u, y0 + y1 u), given in
+// hexadecimal.
+func newAffineTwistPoint(x0, x1, y0, y1 string) twistPoint {
+	var p twistPoint
+	for _, v := range []struct {
+		s string
+		z *fq
+	}{{x0, &p.x.c0}, {x1, &p.x.c1}, {y0, &p.y.c0}, {y1, &p.y.c1}} {
+		bigV, _ := new(big.Int).SetString(v.s, 0)
+		v.z.SetInt(bigV)
+	}
+	p.z.SetOne()
+	p.t.SetOne()
+	return p
+}
+
+// See https://www.rfc-editor.org/rfc/rfc9380.html#appendix-J.10.1.
+func TestTwistPointMapToCurve(t *testing.T) {
+	tests := map[string]struct {
+		u0, u1 string
+		want   twistPoint
+	}{
+		"empty message, u0": {
+			u0: "0x03dbc2cce174e91ba93cbb08f26b917f98194a2ea08d1cce75b2b9cc9f21689d80bd79b594a613d0a68eb807dfdc1cf8",
+			u1: "0x05a2acec64114845711a54199ea339abd125ba38253b70a92c876df10598bd1986b739cad67961eb94f7076511b3b39a",
+			want: newAffineTwistPoint(
+				"0x019ad3fc9c72425a998d7ab1ea0e646a1f6093444fc6965f1cad5a3195a7b1e099c050d57f45e3fa191cc6d75ed7458c",
+				"0x171c88b0b0efb5eb2b88913a9e74fe111a4f68867b59db252ce5868af4d1254bfab77ebde5d61cd1a86fb2fe4a5a1c1d",
+				"0x0ba10604e62bdd9eeeb4156652066167b72c8d743b050fb4c1016c31b505129374f76e03fa127d6a156213576910fef3",
+				"0x0eb22c7a543d3d376e9716a49b72e79a89c9bfe9feee8533ed931cbb5373dde1fbcd7411d8052e02693654f71e15410a",
+			),
+		},
+		"empty message, u1": {
+			u0: "0x02f99798e8a5acdeed60d7e18e9120521ba1f47ec090984662846bc825de191b5b7641148c0dbc237726a334473eee94",
+			u1: "0x145a81e418d4010cc027a68f14391b30074e89e60ee7a22f87217b2f6eb0c4b94c9115b436e6fa4607e95a98de30a435",
+			want: newAffineTwistPoint(
+				"0x113d2b9cd4bd98aee53470b27abc658d91b47a78a51584f3d4b950677cfb8a3e99c24222c406128c91296ef6b45608be",
+				"0x13855912321c5cb793e9d1e88f6f8d342d49c0b0dbac613ee9e17e3c0b3c97dfbb5a49cc3fb45102fdbaf65e0efe2632",
+				"0x0fd3def0b7574a1d801be44fde617162aa2e89da47f464317d9bb5abc3a7071763ce74180883ad7ad9a723a9afafcdca",
+				"0x056f617902b3c0d0f78a9a8cbda43a26b65f602f8786540b9469b060db7b38417915b413ca65f875c130bebfaa59790c",
+			),
+		},
+		"abc, u0": {
+			u0: "0x15f7c0aa8f6b296ab5ff9c2c7581ade64f4ee6f1bf18f55179ff44a2cf355fa53dd2a2158c5ecb17d7c52f63e7195771",
+			u1: "0x01c8067bf4c0ba709aa8b9abc3d1cef589a4758e09ef53732d670fd8739a7274e111ba2fcaa71b3d33df2a3a0c8529dd",
+			want: newAffineTwistPoint(
+				"0x12b2e525281b5f4d2276954e84ac4f42cf4e13b6ac4228624e17760faf94ce5706d53f0ca1952f1c5ef75239aeed55ad",
+				"0x05d8a724db78e570e34100c0bc4a5fa84ad5839359b40398151f37cff5a51de945c563463c9efbdda569850ee5a53e77",
+				"0x02eacdc556d0bdb5d18d22f23dcb086dd106cad713777c7e6407943edbe0b3d1efe391eedf11e977fac55f9b94f2489c",
+				"0x04bbe48bfd5814648d0b9e30f0717b34015d45a861425fabc1ee06fdfce36384ae2c808185e693ae97dcde118f34de41",
+			),
+		},
+		"abc, u1": {
+			u0: "0x187111d5e088b6b9acfdfad078c4dacf72dcd17ca17c82be35e79f8c372a693f60a033b461d81b025864a0ad051a06e4",
+			u1: "0x08b852331c96ed983e497ebc6dee9b75e373d923b729194af8e72a051ea586f3538a6ebb1e80881a082fa2b24df9f566",
+			want: newAffineTwistPoint(
+				"0x19f18cc5ec0c2f055e47c802acc3b0e40c337256a208001dde14b25afced146f37ea3d3ce16834c78175b3ed61f3c537",
+				"0x15b0dadc256a258b4c68ea43605dffa6d312eef215c19e6474b3e101d33b661dfee43b51abbf96fee68fc6043ac56a58",
+				"0x05e47c1781286e61c7ade887512bd9c2cb9f640d3be9cf87ea0bad24bd0ebfe946497b48a581ab6c7d4ca74b5147287f",
+				"0x19f98db2f4a1fcdf56a9ced7b320ea9deecf57c8e59236b0dc21f6ee7229aa9705ce9ac7fe7a31c72edca0d92370c096",
+			),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			bigU0, _ := new(big.Int).SetString(tc.u0, 0)
+			bigU1, _ := new(big.Int).SetString(tc.u1, 0)
+			u := new(fq2)
+			u.c0.SetInt(bigU0)
+			u.c1.SetInt(bigU1)
+			got := new(twistPoint).MapToCurve(u).ToAffine()
+			if *got != tc.want {
+				t.Fatalf("expected: %v, got: %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestTwistPointPsi(t *testing.T) {
+	tests := map[string]struct {
+		input twistPoint
+	}{
+		"generator":    {input: g2Gen.p},
+		"double":       {input: *new(twistPoint).Double(&g2Gen.p)},
+		"scalar mult":  {input: *new(twistPoint).ScalarMult(&g2Gen.p, big.NewInt(1234567))},
+		"map to curve": {input: *new(twistPoint).ClearCofactor(new(twistPoint).EncodeToPoint([]byte("abc"), g2EncodeDomain))},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// psi acts on G2 as multiplication by q = x mod r.
+			want := new(twistPoint).mulByU(&tc.input)
+			want.Neg(want).ToAffine()
+			got := new(twistPoint).Psi(&tc.input)
+			if *got.ToAffine() != *want {
+				t.Fatalf("psi: expected: %v, got: %v", want, got)
+			}
+			want.Psi(want)
+			got = new(twistPoint).Psi2(&tc.input)
+			if *got.ToAffine() != *want.ToAffine() {
+				t.Fatalf("psi2: expected: %v, got: %v", want, got)
+			}
+		})
+	}
+}
+
+func TestTwistPointClearCofactor(t *testing.T) {
+	tests := map[string]struct {
+		msg string
+	}{
+		"empty message": {msg: ""},
+		"abc":           {msg: "abc"},
+		"q128":          {msg: msgQ128},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := new(twistPoint).EncodeToPoint([]byte(tc.msg), g2EncodeDomain)
+			want := new(twistPoint).ScalarMult(p, g2EffectiveCofactor).ToAffine()
+			got := new(twistPoint).ClearCofactor(p).ToAffine()
+			if *got != *want {
+				t.Fatalf("expected: %v, got: %v", want, got)
+			}
+		})
+	}
+}
+
+// ClearCofactor is checked end to end against hash_to_curve: P = h_eff(Q0 + Q1),
+// where Qi is the map to curve of the field element ui = ui0 + ui1 u.
+// See https://www.rfc-editor.org/rfc/rfc9380.html#appendix-J.10.1.
+func TestTwistPointClearCofactorHashToCurve(t *testing.T) {
+	tests := map[string]struct {
+		u00, u01, u10, u11 string
+		want               twistPoint
+	}{
+		"empty message": {
+			u00: "0x03dbc2cce174e91ba93cbb08f26b917f98194a2ea08d1cce75b2b9cc9f21689d80bd79b594a613d0a68eb807dfdc1cf8",
+			u01: "0x05a2acec64114845711a54199ea339abd125ba38253b70a92c876df10598bd1986b739cad67961eb94f7076511b3b39a",
+			u10: "0x02f99798e8a5acdeed60d7e18e9120521ba1f47ec090984662846bc825de191b5b7641148c0dbc237726a334473eee94",
+			u11: "0x145a81e418d4010cc027a68f14391b30074e89e60ee7a22f87217b2f6eb0c4b94c9115b436e6fa4607e95a98de30a435",
+			want: newAffineTwistPoint(
+				"0x0141ebfbdca40eb85b87142e130ab689c673cf60f1a3e98d69335266f30d9b8d4ac44c1038e9dcdd5393faf5c41fb78a",
+				"0x05cb8437535e20ecffaef7752baddf98034139c38452458baeefab379ba13dff5bf5dd71b72418717047f5b0f37da03d",
+				"0x0503921d7f6a12805e72940b963c0cf3471c7b2a524950ca195d11062ee75ec076daf2d4bc358c4b190c0c98064fdd92",
+				"0x12424ac32561493f3fe3c260708a12b7c620e7be00099a974e259ddc7d1f6395c3c811cdd19f1e8dbf3e9ecfdcbab8d6",
+			),
+		},
+		"abc": {
+			u00: "0x15f7c0aa8f6b296ab5ff9c2c7581ade64f4ee6f1bf18f55179ff44a2cf355fa53dd2a2158c5ecb17d7c52f63e7195771",
+			u01: "0x01c8067bf4c0ba709aa8b9abc3d1cef589a4758e09ef53732d670fd8739a7274e111ba2fcaa71b3d33df2a3a0c8529dd",
+			u10: "0x187111d5e088b6b9acfdfad078c4dacf72dcd17ca17c82be35e79f8c372a693f60a033b461d81b025864a0ad051a06e4",
+			u11: "0x08b852331c96ed983e497ebc6dee9b75e373d923b729194af8e72a051ea586f3538a6ebb1e80881a082fa2b24df9f566",
+			want: newAffineTwistPoint(
+				"0x02c2d18e033b960562aae3cab37a27ce00d80ccd5ba4b7fe0e7a210245129dbec7780ccc7954725f4168aff2787776e6",
+				"0x139cddbccdc5e91b9623efd38c49f81a6f83f175e80b06fc374de9eb4b41dfe4ca3a230ed250fbe3a2acf73a41177fd8",
+				"0x1787327b68159716a37440985269cf584bcb1e621d3a7202be6ea05c4cfe244aeb197642555a0645fb87bf7466b2ba48",
+				"0x00aa65dae3c8d732d10ecd2c50f8a1baf3001578f71c694e03866e9f3d49ac1e1ce70dd94a733534f106d4cec0eddd16",
+			),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := new(twistPoint)
+			for _, u := range [][2]string{{tc.u00, tc.u01}, {tc.u10, tc.u11}} {
+				bigU0, _ := new(big.Int).SetString(u[0], 0)
+				bigU1, _ := new(big.Int).SetString(u[1], 0)
+				ui := new(fq2)
+				ui.c0.SetInt(bigU0)
+				ui.c1.SetInt(bigU1)
+				p.Add(p, new(twistPoint).MapToCurve(ui))
+			}
+			got := new(twistPoint).ClearCofactor(p).ToAffine()
+			if *got != tc.want {
+				t.Fatalf("expected: %v, got: %v", tc.want, got)
+			}
+		})
+	}
+}
+
 /*
 func TestTwistPointAdd(t *testing.T) {
 	testCases := []struct {
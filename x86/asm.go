@@ -1,6 +1,9 @@
 package main
 
 import (
+	"flag"
+	"log"
+
 	. "github.com/mmcloughlin/avo/build"
 	. "github.com/mmcloughlin/avo/operand"
 	. "github.com/mmcloughlin/avo/reg"
@@ -152,6 +155,139 @@ func basicMulBMI2(product [fqLen]Register, z Mem, x Mem, y Mem) {
 	}
 }
 
+func fqSqr() {
+	x := Mem{Base: Load(Param("x"), GP64())}
+	fqLarge := AllocLocal(96)
+	product := [fqLen]Register{GP64(), GP64(), GP64(), GP64(), GP64(), GP64()}
+	carryMul, carrySum := GP64(), GP64()
+	CMPB(hasBMI2, zero)
+	JE(LabelRef("fallback"))
+	basicSqrBMI2(product, carryMul, carrySum, fqLarge, x)
+	fqREDCBMI2(product, carrySum, fqLarge)
+	JMP(LabelRef("out"))
+	Label("fallback")
+	basicSqr(product, carryMul, carrySum, fqLarge, x)
+	fqREDC(product, carryMul, carrySum, fqLarge)
+	Label("out")
+	z := Mem{Base: Load(Param("z"), x.Base)}
+	fqStore(z, product)
+	RET()
+}
+
+// basicSqr computes x² with the symmetric product: the products x[i]x[j], i < j,
+// are computed once and doubled before the squares x[i]² are added.
+func basicSqr(product [fqLen]Register, carry, carryDouble Register, z Mem, x Mem) {
+	for i := 0; i < (fqLen - 1); i++ {
+		xi := x.Offset(i * 8)
+		row := product[:fqLen-i]
+		for j := range row[1:] {
+			MOVQ(xi, RAX)
+			MULQ(x.Offset((i + j + 1) * 8))
+			if j == 0 {
+				MOVQ(RAX, row[0])
+			} else {
+				ADDQ(RAX, row[j])
+				ADCQ(zero, RDX)
+			}
+			MOVQ(RDX, row[j+1])
+		}
+		sqrAddRow(row, z.Offset((2*i+1)*8), i == 0)
+	}
+
+	sqrDoubleAdd(product, carry, carryDouble, z, func(i int) (Register, Register) {
+		MOVQ(x.Offset(i*8), RAX)
+		MULQ(RAX)
+		return RAX, RDX
+	})
+}
+
+func basicSqrBMI2(product [fqLen]Register, carry, carryDouble Register, z Mem, x Mem) {
+	for i := 0; i < (fqLen - 1); i++ {
+		MOVQ(x.Offset(i*8), RDX)
+		row := product[:fqLen-i]
+		for j := range row[1:] {
+			if j == 0 {
+				MULXQ(x.Offset((i+j+1)*8), row[0], row[1])
+			} else {
+				MULXQ(x.Offset((i+j+1)*8), RAX, row[j+1])
+				ADDQ(RAX, row[j])
+				ADCQ(zero, row[j+1])
+			}
+		}
+		sqrAddRow(row, z.Offset((2*i+1)*8), i == 0)
+	}
+
+	sqrDoubleAdd(product, carry, carryDouble, z, func(i int) (Register, Register) {
+		MOVQ(x.Offset(i*8), RDX)
+		MULXQ(RDX, RAX, RBX)
+		return RAX, RBX
+	})
+}
+
+// sqrAddRow adds the row of products x[i]x[j], i < j, to z, where the last word
+// of the row is new to z.
+func sqrAddRow(row []Register, z Mem, first bool) {
+	if !first {
+		ADDQ(z, row[0])
+		for j, word := range row[1 : len(row)-1] {
+			ADCQ(z.Offset((j+1)*8), word)
+		}
+		ADCQ(zero, row[len(row)-1])
+	}
+	for j, word := range row {
+		MOVQ(word, z.Offset(j*8))
+	}
+}
+
+// sqrDoubleAdd doubles the sum of the products x[i]x[j], i < j, held in
+// z[1..2*fqLen-2], and adds the squares x[i]², given by square. z is processed
+// in registers, a half at a time.
+func sqrDoubleAdd(product [fqLen]Register, carry, carryDouble Register, z Mem, square func(i int) (Register, Register)) {
+	XORQ(product[0], product[0])
+	for i, word := range product[1:] {
+		MOVQ(z.Offset((i+1)*8), word)
+	}
+	ADDQ(product[1], product[1])
+	for _, word := range product[2:] {
+		ADCQ(word, word)
+	}
+	MOVQ(U32(0), carryDouble)
+	ADCQ(zero, carryDouble)
+
+	XORQ(carry, carry)
+	sqrAddSquares(product, carry, 0, square)
+	fqStore(z, product)
+
+	for i, word := range product[:fqLen-1] {
+		MOVQ(z.Offset((fqLen+i)*8), word)
+	}
+	XORQ(product[fqLen-1], product[fqLen-1])
+	ADDQ(product[0], product[0])
+	for _, word := range product[1:] {
+		ADCQ(word, word)
+	}
+	// the lowest bit is 0 after the doubling.
+	ORQ(carryDouble, product[0])
+
+	sqrAddSquares(product, carry, fqLen/2, square)
+	fqStore(z.Offset(fqLen*8), product)
+}
+
+// sqrAddSquares adds the squares x[first..first+fqLen/2-1]² to product, with the
+// carry of the previous squares.
+func sqrAddSquares(product [fqLen]Register, carry Register, first int, square func(i int) (Register, Register)) {
+	for i := 0; i < fqLen/2; i++ {
+		lo, hi := square(first + i)
+		// hi is at most 2^64-2, so the carry cannot overflow it.
+		ADDQ(carry, lo)
+		ADCQ(zero, hi)
+		ADDQ(lo, product[2*i])
+		ADCQ(hi, product[2*i+1])
+		MOVQ(U32(0), carry)
+		ADCQ(zero, carry)
+	}
+}
+
 func fqREDC(product [fqLen]Register, carryMul Register, carrySum Register, x Mem) {
 	XORQ(carrySum, carrySum)
 	q := Mem{Symbol: Symbol{Name: "·q64"}, Base: StaticBase}
@@ -250,7 +386,7 @@ func fqREDCBMI2(product [fqLen]Register, carrySum Register, x Mem) {
 	fqMod(product)
 }
 
-func main() {
+func fqRoutines() {
 	TEXT("fqAdd", 0, "func(z *[6]uint64, x *[6]uint64, y *[6]uint64)")
 	Doc("fqAdd sets z to the sum x+y.")
 	x := Mem{Base: Load(Param("x"), GP64())}
@@ -276,46 +412,14 @@ func main() {
 
 	RET()
 
-	/*
-		TEXT("fqSub", 0, "func(z *[6]uint64, x *[6]uint64, y *[6]uint64)")
-		Doc("fqSub sets z to the difference x-y.")
-		y = Mem{Base: Load(Param("y"), GP64())}
-		regs = fqNeg(y)
-		x = Mem{Base: Load(Param("x"), y.Base)}
-		ADDQ(x.Offset(0), regs[0])
-		for i, reg := range regs[1:] {
-			ADCQ(x.Offset((i+1)*8), reg)
-		}
-
-		z = Mem{Base: Load(Param("z"), x.Base)}
-		fqStore(z, fqMod(regs))
-
-		RET()
-	*/
-
 	TEXT("fqSub", 0, "func(z *[6]uint64, x *[6]uint64, y *[6]uint64)")
 	Doc("fqSub sets z to the difference x-y.")
-	x = Mem{Base: Load(Param("x"), GP64())}
-	regs = fqLoad(x)
-	y = Mem{Base: Load(Param("y"), x.Base)}
-	SUBQ(y.Offset(0), regs[0])
-	for i, ri := range regs[1:] {
-		SBBQ(y.Offset((i+1)*8), ri)
-	}
-	regsQ := [fqLen]Register{GP64(), GP64(), GP64(), GP64(), GP64(), GP64()}
-	q := Mem{Symbol: Symbol{Name: "·q64"}, Base: StaticBase}
-	for i, ri := range regsQ {
-		MOVQ(q.Offset(i*8), ri)
-	}
-	r0 := y.Base
-	XORQ(r0, r0)
-	for _, ri := range regsQ {
-		CMOVQCC(r0, ri)
-	}
-
-	ADDQ(regsQ[0], regs[0])
-	for i, ri := range regs[1:] {
-		ADCQ(regsQ[i], ri)
+	y = Mem{Base: Load(Param("y"), GP64())}
+	regs = fqNeg(y)
+	x = Mem{Base: Load(Param("x"), y.Base)}
+	ADDQ(x.Offset(0), regs[0])
+	for i, reg := range regs[1:] {
+		ADCQ(x.Offset((i+1)*8), reg)
 	}
 
 	z = Mem{Base: Load(Param("z"), x.Base)}
@@ -327,6 +431,206 @@ func main() {
 	Doc("fqMul sets z to the product x*y.")
 	fqMul()
 
+	TEXT("fqSqr", 0, "func(z *[6]uint64, x *[6]uint64)")
+	Doc("fqSqr sets z to x².")
+	fqSqr()
+}
+
+const (
+	frLen = 4
+	rK64  = 0xfffffffeffffffff
+)
+
+var r64 = Mem{Symbol: Symbol{Name: "·r64"}, Base: StaticBase}
+
+// The fr routines use physical registers: four words leave enough of them to
+// never touch BP, so the routines do not need a frame to preserve it.
+var (
+	// frT holds the frLen+2 words of the accumulator of the multiplication.
+	frT = [frLen + 2]Register{R8, R9, R10, R11, R12, R13}
+
+	// frTmp holds the copy of a value while it is reduced.
+	frTmp = [frLen]Register{R14, R15, RBX, RCX}
+)
+
+func frLoad(src Mem, regs []Register) {
+	for i, ri := range regs {
+		MOVQ(src.Offset(8*i), ri)
+	}
+}
+
+func frStore(dst Mem, regs []Register) {
+	for i, ri := range regs {
+		MOVQ(ri, dst.Offset(8*i))
+	}
+}
+
+// frMod subtracts r from regs if regs is at least r, in constant time.
+func frMod(regs []Register) {
+	for i, ri := range regs {
+		MOVQ(ri, frTmp[i])
+	}
+
+	SUBQ(r64.Offset(0), frTmp[0])
+	for i := 1; i < frLen; i++ {
+		SBBQ(r64.Offset(i*8), frTmp[i])
+	}
+
+	for i, ri := range regs {
+		CMOVQCC(frTmp[i], ri)
+	}
+}
+
+// frMulStep adds a*b to the words t, where a is RDX with BMI2 and a register
+// otherwise, and propagates the carry into t[frLen] and t[frLen+1]. c is used
+// for the carries between words.
+func frMulStep(t []Register, a Op, b Mem, c Register, bmi2 bool) {
+	for j := 0; j < frLen; j++ {
+		hi := RDX
+		if bmi2 {
+			hi = RBX
+			MULXQ(b.Offset(j*8), RAX, hi)
+		} else {
+			MOVQ(a, RAX)
+			MULQ(b.Offset(j * 8))
+		}
+		ADDQ(RAX, t[j])
+		ADCQ(zero, hi)
+		if j > 0 {
+			ADDQ(c, t[j])
+			ADCQ(zero, hi)
+		}
+		MOVQ(hi, c)
+	}
+	ADDQ(c, t[frLen])
+	ADCQ(zero, t[frLen+1])
+}
+
+// frMulCIOS multiplies x and y into frT with the interleaved montgomery
+// multiplication and reduction, and returns the registers of the product.
+// See https://www.microsoft.com/en-us/research/wp-content/uploads/1996/01/j37acmon.pdf - CIOS.
+func frMulCIOS(x, y Mem, bmi2 bool) []Register {
+	t := frT[:]
+	c, m := R14, R15
+	for i := 0; i < frLen; i++ {
+		// t += x y[i]
+		if bmi2 {
+			MOVQ(y.Offset(i*8), RDX)
+			frMulStep(t, RDX, x, c, true)
+		} else {
+			frMulStep(t, y.Offset(i*8), x, c, false)
+		}
+
+		// t += m r, where m makes the lowest word 0.
+		MOVQ(Imm(rK64), m)
+		IMULQ(t[0], m)
+		if bmi2 {
+			MOVQ(m, RDX)
+			frMulStep(t, RDX, r64, c, true)
+		} else {
+			frMulStep(t, m, r64, c, false)
+		}
+
+		// t /= 2^64; the register of the lowest word, now 0, becomes the
+		// highest.
+		t = append(t[1:], t[0])
+	}
+
+	return t[:frLen]
+}
+
+func frMul() {
+	x := Mem{Base: Load(Param("x"), RSI)}
+	y := Mem{Base: Load(Param("y"), RDI)}
+	for _, ti := range frT {
+		XORQ(ti, ti)
+	}
+
+	CMPB(hasBMI2, zero)
+	JE(LabelRef("fallback"))
+	product := frMulCIOS(x, y, true)
+	JMP(LabelRef("out"))
+	Label("fallback")
+	frMulCIOS(x, y, false)
+	Label("out")
+
+	frMod(product)
+	z := Mem{Base: Load(Param("z"), RSI)}
+	frStore(z, product)
+	RET()
+}
+
+func frRoutines() {
+	TEXT("frAdd", 0, "func(z *[4]uint64, x *[4]uint64, y *[4]uint64)")
+	Doc("frAdd sets z to the sum x+y.")
+	x := Mem{Base: Load(Param("x"), RSI)}
+	y := Mem{Base: Load(Param("y"), RDI)}
+	t := frT[:frLen]
+	frLoad(x, t)
+	ADDQ(y.Offset(0), t[0])
+	for i := 1; i < frLen; i++ {
+		ADCQ(y.Offset(i*8), t[i])
+	}
+	frMod(t)
+	z := Mem{Base: Load(Param("z"), RSI)}
+	frStore(z, t)
+	RET()
+
+	TEXT("frNeg", 0, "func(z *[4]uint64, x *[4]uint64)")
+	Doc("frNeg sets z to -x.")
+	x = Mem{Base: Load(Param("x"), RSI)}
+	frLoad(r64, t)
+	SUBQ(x.Offset(0), t[0])
+	for i := 1; i < frLen; i++ {
+		SBBQ(x.Offset(i*8), t[i])
+	}
+	// -0 is r before the reduction.
+	frMod(t)
+	z = Mem{Base: Load(Param("z"), RSI)}
+	frStore(z, t)
+	RET()
+
+	TEXT("frSub", 0, "func(z *[4]uint64, x *[4]uint64, y *[4]uint64)")
+	Doc("frSub sets z to the difference x-y.")
+	x = Mem{Base: Load(Param("x"), RSI)}
+	y = Mem{Base: Load(Param("y"), RDI)}
+	frLoad(x, t)
+	SUBQ(y.Offset(0), t[0])
+	for i := 1; i < frLen; i++ {
+		SBBQ(y.Offset(i*8), t[i])
+	}
+	// if x < y, then r is added back. MOVQ leaves the borrow untouched.
+	frLoad(r64, frTmp[:])
+	MOVQ(U32(0), RAX)
+	for _, ri := range frTmp {
+		CMOVQCC(RAX, ri)
+	}
+	ADDQ(frTmp[0], t[0])
+	for i := 1; i < frLen; i++ {
+		ADCQ(frTmp[i], t[i])
+	}
+	z = Mem{Base: Load(Param("z"), RSI)}
+	frStore(z, t)
+	RET()
+
+	TEXT("frMul", 0, "func(z *[4]uint64, x *[4]uint64, y *[4]uint64)")
+	Doc("frMul sets z to the product x*y.")
+	frMul()
+}
+
+func main() {
+	field := flag.String("field", "fq", "field of the generated routines, fq or fr")
+	flag.Parse()
+
+	switch *field {
+	case "fq":
+		fqRoutines()
+	case "fr":
+		frRoutines()
+	default:
+		log.Fatalf("unknown field %q", *field)
+	}
+
 	ConstraintExpr("amd64,!generic")
 	Generate()
 }